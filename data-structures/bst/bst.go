@@ -1,83 +1,250 @@
 package bst
 
-type BstNode struct {
-	Key int
-	Left *BstNode
-	Right *BstNode
+import (
+	"cmp"
+	"fmt"
+)
+
+// Node is a tree node holding a key/value pair. Nodes cache their subtree
+// height, so balanced trees can rebalance without recomputing it, and their
+// subtree size, for the order-statistic queries.
+type Node[K, V any] struct {
+	Key    K
+	Value  V
+	Left   *Node[K, V]
+	Right  *Node[K, V]
+	height int
+	size   int
 }
 
+// BstNode is a node of a Tree. Its cached height and size are unexported, so
+// nodes must be created through Insert: a hand-built tree such as
+// &Tree{Root: &BstNode{Key: 1}} gets wrong Height, Rank and Select results
+// and fails Validate.
+type BstNode = Node[int, struct{}]
+
+// Tree is an int-keyed binary search tree. It runs on the same generic core
+// as Map rather than wrapping one, which keeps Root exported and lets a Tree
+// be unbalanced. The zero value is a plain, unbalanced BST; use NewBalanced
+// for a tree that rebalances itself (AVL) on every Insert and Delete.
 type Tree struct {
-	Root *BstNode
+	Root     *BstNode
+	balanced bool
+}
+
+func NewBalanced() *Tree {
+	return &Tree{balanced: true}
+}
+
+func (t *Tree) ops() ops[int, struct{}] {
+	return ops[int, struct{}]{cmp: cmp.Compare[int], balanced: t.balanced}
 }
 
 func (t *Tree) Search(key int) bool {
-	for n := t.Root; n != nil; {
-		switch {
-		case key < n.Key:
-			n = n.Left
-		case key > n.Key:
-			n = n.Right
-		default:
-			return true
-		}
-	}
-	return false
+	return t.ops().find(t.Root, key) != nil
 }
 
 func (t *Tree) Insert(key int) {
-	if t.Root == nil {
-		t.Root = &BstNode{Key: key}
-		return
-	}
-	n := t.Root
-	for {
-		if key < n.Key {
-			if n.Left == nil {
-				n.Left = &BstNode{Key: key}
-				return
-			}
+	t.Root, _ = t.ops().insert(t.Root, key, struct{}{})
+}
+
+func (t *Tree) Delete(key int) {
+	t.Root, _ = t.ops().deleteNode(t.Root, key)
+}
+
+// Height returns the number of nodes on the longest root-to-leaf path
+// (0 for an empty tree).
+func (t *Tree) Height() int {
+	return height(t.Root)
+}
+
+// Validate checks the BST ordering, the cached node heights and sizes and,
+// for a balanced tree, the AVL balance factor of every node.
+func (t *Tree) Validate() error {
+	_, err := t.ops().validate(t.Root, nil, nil)
+	return err
+}
+
+// ---------------- Generic core ----------------
+
+// ops holds what the tree algorithms need to know about a tree: how to order
+// keys, whether to rebalance after mutations and, for augmented trees, how to
+// recompute per-node data from a node's children.
+type ops[K, V any] struct {
+	cmp      func(a, b K) int
+	balanced bool
+	// augment, if set, runs whenever a node's cached height and size are
+	// refreshed, after its children have been refreshed. It lets a tree keep
+	// extra subtree summaries in Value, like IntervalTree's max end.
+	augment func(n *Node[K, V])
+}
+
+func (o ops[K, V]) find(n *Node[K, V], key K) *Node[K, V] {
+	for n != nil {
+		switch c := o.cmp(key, n.Key); {
+		case c < 0:
 			n = n.Left
-		} else if key > n.Key {
-			if n.Right == nil {
-				n.Right = &BstNode{Key: key}
-				return
-			}
+		case c > 0:
 			n = n.Right
-		} else {
-			return
+		default:
+			return n
 		}
 	}
+	return nil
 }
 
-func (t *Tree) Delete(key int) {
-	t.Root = deleteNode(t.Root, key)
+// insert adds key with val, replacing the value if key is already present.
+// It returns the new subtree root and whether a node was added.
+func (o ops[K, V]) insert(n *Node[K, V], key K, val V) (*Node[K, V], bool) {
+	if n == nil {
+		return &Node[K, V]{Key: key, Value: val, height: 1, size: 1}, true
+	}
+	var added bool
+	switch c := o.cmp(key, n.Key); {
+	case c < 0:
+		n.Left, added = o.insert(n.Left, key, val)
+	case c > 0:
+		n.Right, added = o.insert(n.Right, key, val)
+	default:
+		n.Value = val
+		if o.augment != nil {
+			o.augment(n)
+		}
+		return n, false
+	}
+	return o.fixup(n), added
 }
 
-func deleteNode(n *BstNode, key int) *BstNode {
+// deleteNode removes key from the subtree rooted at n. It returns the new
+// subtree root and whether a node was removed.
+func (o ops[K, V]) deleteNode(n *Node[K, V], key K) (*Node[K, V], bool) {
 	if n == nil {
-		return nil
+		return nil, false
 	}
-	if key < n.Key {
-		n.Left = deleteNode(n.Left, key)
-		return n
+	var removed bool
+	c := o.cmp(key, n.Key)
+	if c < 0 {
+		n.Left, removed = o.deleteNode(n.Left, key)
+		return o.fixup(n), removed
 	}
-	if key > n.Key {
-		n.Right = deleteNode(n.Right, key)
-		return n
+	if c > 0 {
+		n.Right, removed = o.deleteNode(n.Right, key)
+		return o.fixup(n), removed
 	}
 
 	if n.Left == nil {
-		return n.Right
+		return n.Right, true
 	}
 	if n.Right == nil {
-		return n.Left
+		return n.Left, true
 	}
 
 	succ := n.Right
 	for succ.Left != nil {
 		succ = succ.Left
 	}
-	n.Key = succ.Key
-	n.Right = deleteNode(n.Right, succ.Key)
+	n.Key, n.Value = succ.Key, succ.Value
+	n.Right, _ = o.deleteNode(n.Right, succ.Key)
+	return o.fixup(n), true
+}
+
+func (o ops[K, V]) validate(n *Node[K, V], lo, hi *K) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if (lo != nil && o.cmp(n.Key, *lo) <= 0) || (hi != nil && o.cmp(n.Key, *hi) >= 0) {
+		return 0, fmt.Errorf("key %v out of order", n.Key)
+	}
+	lh, err := o.validate(n.Left, lo, &n.Key)
+	if err != nil {
+		return 0, err
+	}
+	rh, err := o.validate(n.Right, &n.Key, hi)
+	if err != nil {
+		return 0, err
+	}
+	h := 1 + max(lh, rh)
+	if n.height != h {
+		return 0, fmt.Errorf("key %v: cached height %d, actual %d", n.Key, n.height, h)
+	}
+	if sz := 1 + size(n.Left) + size(n.Right); n.size != sz {
+		return 0, fmt.Errorf("key %v: cached size %d, actual %d", n.Key, n.size, sz)
+	}
+	if o.balanced && (lh-rh > 1 || rh-lh > 1) {
+		return 0, fmt.Errorf("key %v unbalanced: left height %d, right height %d", n.Key, lh, rh)
+	}
+	return h, nil
+}
+
+// ---------------- AVL helpers ----------------
+
+func height[K, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[K, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *Node[K, V]) update() {
+	n.height = 1 + max(height(n.Left), height(n.Right))
+	n.size = 1 + size(n.Left) + size(n.Right)
+}
+
+// update refreshes n's cached fields, including any augmentation.
+func (o ops[K, V]) update(n *Node[K, V]) {
+	n.update()
+	if o.augment != nil {
+		o.augment(n)
+	}
+}
+
+func (n *Node[K, V]) balance() int {
+	return height(n.Left) - height(n.Right)
+}
+
+// fixup refreshes n's cached fields and, if the tree is balanced, restores
+// the AVL invariant at n. It returns the new root of the subtree.
+func (o ops[K, V]) fixup(n *Node[K, V]) *Node[K, V] {
+	o.update(n)
+	if !o.balanced {
+		return n
+	}
+	switch b := n.balance(); {
+	case b > 1:
+		if n.Left.balance() < 0 {
+			n.Left = o.rotateLeft(n.Left)
+		}
+		return o.rotateRight(n)
+	case b < -1:
+		if n.Right.balance() > 0 {
+			n.Right = o.rotateRight(n.Right)
+		}
+		return o.rotateLeft(n)
+	}
 	return n
-}
\ No newline at end of file
+}
+
+func (o ops[K, V]) rotateLeft(n *Node[K, V]) *Node[K, V] {
+	r := n.Right
+	n.Right = r.Left
+	r.Left = n
+	o.update(n)
+	o.update(r)
+	return r
+}
+
+func (o ops[K, V]) rotateRight(n *Node[K, V]) *Node[K, V] {
+	l := n.Left
+	n.Left = l.Right
+	l.Right = n
+	o.update(n)
+	o.update(l)
+	return l
+}
@@ -1,6 +1,9 @@
 package bst
 
-import "testing"
+import (
+	"math/rand/v2"
+	"testing"
+)
 
 func TestBst_Insert_Search(t *testing.T) {
 	want := []int{5, 3, 8, 2, 4, 7, 9}
@@ -9,7 +12,7 @@ func TestBst_Insert_Search(t *testing.T) {
 	for _, v := range want {
 		bst.Insert(v)
 	}
-	
+
 	// positive assertion
 	for _, v := range want {
 		if !bst.Search(v) {
@@ -23,4 +26,60 @@ func TestBst_Insert_Search(t *testing.T) {
 			t.Fatalf("expected not to find %d in tree", v)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestBst_Unbalanced_Degenerates(t *testing.T) {
+	tree := &Tree{}
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i)
+	}
+	if h := tree.Height(); h != 100 {
+		t.Fatalf("expected height 100 for sorted inserts, got %d", h)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+}
+
+func TestBst_Balanced_MonotonicInsert(t *testing.T) {
+	tree := NewBalanced()
+	for i := 1; i <= 1000; i++ {
+		tree.Insert(i)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("after Insert(%d): %v", i, err)
+		}
+	}
+	// An AVL tree with n nodes has height < 1.45*log2(n+2), i.e. < 15 for n=1000.
+	if h := tree.Height(); h > 14 {
+		t.Fatalf("expected height <= 14, got %d", h)
+	}
+	for i := 1; i <= 1000; i++ {
+		if !tree.Search(i) {
+			t.Fatalf("expected to find %d", i)
+		}
+	}
+}
+
+func TestBst_Balanced_RandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := NewBalanced()
+	ref := make(map[int]bool)
+
+	for i := 0; i < 5000; i++ {
+		key := r.IntN(500)
+		if r.IntN(3) == 0 {
+			tree.Delete(key)
+			delete(ref, key)
+		} else {
+			tree.Insert(key)
+			ref[key] = true
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("op %d: %v", i, err)
+		}
+	}
+	for key := 0; key < 500; key++ {
+		if got := tree.Search(key); got != ref[key] {
+			t.Fatalf("Search(%d) = %v, want %v", key, got, ref[key])
+		}
+	}
+}
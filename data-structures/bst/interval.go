@@ -0,0 +1,130 @@
+package bst
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// Interval is a closed range [Start, End].
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (iv Interval) overlaps(lo, hi int) bool {
+	return iv.Start <= hi && lo <= iv.End
+}
+
+func (iv Interval) compare(other Interval) int {
+	if c := cmp.Compare(iv.Start, other.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(iv.End, other.End)
+}
+
+var ErrInvalidInterval = errors.New("bst: interval start is after its end")
+
+// intervalNode stores an interval as its key and, in Value, the largest End
+// in its subtree, which intervalOps keeps current through every insert,
+// delete and rotation.
+type intervalNode = Node[Interval, int]
+
+var intervalOps = ops[Interval, int]{
+	cmp:      Interval.compare,
+	balanced: true,
+	augment: func(n *intervalNode) {
+		n.Value = max(n.Key.End, maxEnd(n.Left, n.Key.End), maxEnd(n.Right, n.Key.End))
+	},
+}
+
+// IntervalTree stores a set of intervals in an AVL tree ordered by (Start,
+// End). Each node also caches the largest End in its subtree, which lets
+// overlap queries skip any subtree that ends before the query starts.
+type IntervalTree struct {
+	root *intervalNode
+}
+
+func (t *IntervalTree) Len() int {
+	return size(t.root)
+}
+
+// Insert adds iv to the tree. Inserting an interval that is already present
+// is a no-op.
+func (t *IntervalTree) Insert(iv Interval) error {
+	if iv.Start > iv.End {
+		return fmt.Errorf("%w: [%d, %d]", ErrInvalidInterval, iv.Start, iv.End)
+	}
+	t.root, _ = intervalOps.insert(t.root, iv, iv.End)
+	return nil
+}
+
+// Delete removes iv and reports whether it was present.
+func (t *IntervalTree) Delete(iv Interval) bool {
+	var removed bool
+	t.root, removed = intervalOps.deleteNode(t.root, iv)
+	return removed
+}
+
+// Overlapping yields every interval that shares at least one point with
+// [lo, hi], ordered by (Start, End).
+func (t *IntervalTree) Overlapping(lo, hi int) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		overlapping(t.root, lo, hi, yield)
+	}
+}
+
+// Stab yields every interval containing point.
+func (t *IntervalTree) Stab(point int) iter.Seq[Interval] {
+	return t.Overlapping(point, point)
+}
+
+// Validate checks the ordering, AVL and max-end invariants of the tree.
+func (t *IntervalTree) Validate() error {
+	if _, err := intervalOps.validate(t.root, nil, nil); err != nil {
+		return err
+	}
+	return validateMaxEnd(t.root)
+}
+
+func overlapping(n *intervalNode, lo, hi int, yield func(Interval) bool) bool {
+	// Nothing in this subtree ends late enough to reach lo.
+	if n == nil || n.Value < lo {
+		return true
+	}
+	if !overlapping(n.Left, lo, hi, yield) {
+		return false
+	}
+	// Everything to the right starts after n, so after hi too.
+	if n.Key.Start > hi {
+		return true
+	}
+	if n.Key.overlaps(lo, hi) && !yield(n.Key) {
+		return false
+	}
+	return overlapping(n.Right, lo, hi, yield)
+}
+
+func maxEnd(n *intervalNode, fallback int) int {
+	if n == nil {
+		return fallback
+	}
+	return n.Value
+}
+
+func validateMaxEnd(n *intervalNode) error {
+	if n == nil {
+		return nil
+	}
+	if err := validateMaxEnd(n.Left); err != nil {
+		return err
+	}
+	if err := validateMaxEnd(n.Right); err != nil {
+		return err
+	}
+	if e := n.Key.End; n.Value != max(e, maxEnd(n.Left, e), maxEnd(n.Right, e)) {
+		return fmt.Errorf("interval %v: stale max end %d", n.Key, n.Value)
+	}
+	return nil
+}
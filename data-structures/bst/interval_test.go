@@ -0,0 +1,114 @@
+package bst
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIntervalTree_Overlapping(t *testing.T) {
+	var tree IntervalTree
+	for _, iv := range []Interval{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+		if err := tree.Insert(iv); err != nil {
+			t.Fatalf("Insert(%v): %v", iv, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []Interval
+	}{
+		{"before_all", 0, 4, nil},
+		{"touches_start", 0, 5, []Interval{{5, 20}}},
+		{"middle", 14, 16, []Interval{{5, 20}, {10, 30}, {12, 15}, {15, 20}}},
+		{"touches_end", 40, 50, []Interval{{30, 40}}},
+		{"after_all", 41, 50, nil},
+		{"covers_all", 0, 100, []Interval{{5, 20}, {10, 30}, {12, 15}, {15, 20}, {17, 19}, {30, 40}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tree.Overlapping(tc.lo, tc.hi)); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Overlapping(%d, %d) got %v, want %v", tc.lo, tc.hi, got, tc.want)
+			}
+		})
+	}
+
+	if got, want := slices.Collect(tree.Stab(30)), []Interval{{10, 30}, {30, 40}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stab(30) got %v, want %v", got, want)
+	}
+}
+
+func TestIntervalTree_InsertDelete(t *testing.T) {
+	var tree IntervalTree
+	if err := tree.Insert(Interval{5, 1}); !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+
+	tree.Insert(Interval{1, 5})
+	tree.Insert(Interval{1, 5})
+	if tree.Len() != 1 {
+		t.Fatalf("expected duplicate insert to be ignored, Len got %d", tree.Len())
+	}
+	if !tree.Delete(Interval{1, 5}) || tree.Delete(Interval{1, 5}) {
+		t.Fatalf("expected exactly one successful Delete")
+	}
+	if got := slices.Collect(tree.Stab(3)); len(got) != 0 {
+		t.Fatalf("expected no intervals after delete, got %v", got)
+	}
+
+	// Re-inserting the root must keep the max end its child contributes.
+	tree.Insert(Interval{5, 6})
+	tree.Insert(Interval{1, 100})
+	tree.Insert(Interval{5, 6})
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("after duplicate insert: %v", err)
+	}
+	if got := slices.Collect(tree.Stab(50)); !slices.Equal(got, []Interval{{1, 100}}) {
+		t.Fatalf("Stab(50) got %v, want [{1 100}]", got)
+	}
+}
+
+func TestIntervalTree_RandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	var tree IntervalTree
+	ref := make(map[Interval]bool)
+
+	for i := 0; i < 3000; i++ {
+		start := r.IntN(1000)
+		iv := Interval{start, start + r.IntN(50)}
+		if r.IntN(3) == 0 && len(ref) > 0 {
+			// Delete something that is likely present.
+			for k := range ref {
+				iv = k
+				break
+			}
+			tree.Delete(iv)
+			delete(ref, iv)
+		} else {
+			tree.Insert(iv)
+			ref[iv] = true
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("op %d: %v", i, err)
+		}
+
+		lo := r.IntN(1000)
+		hi := lo + r.IntN(30)
+		var want []Interval
+		for k := range ref {
+			if k.Start <= hi && lo <= k.End {
+				want = append(want, k)
+			}
+		}
+		slices.SortFunc(want, Interval.compare)
+		if got := slices.Collect(tree.Overlapping(lo, hi)); !slices.Equal(got, want) {
+			t.Fatalf("op %d: Overlapping(%d, %d) got %v, want %v", i, lo, hi, got, want)
+		}
+	}
+	if tree.Len() != len(ref) {
+		t.Fatalf("Len got %d, want %d", tree.Len(), len(ref))
+	}
+}
@@ -0,0 +1,110 @@
+package bst
+
+import "iter"
+
+// The iterators below don't hold on to nodes between steps: after each yield
+// they look up the next key from the current root. That costs O(log n) per
+// step on a balanced tree, but means the tree may be modified mid-scan
+// (including deleting the key just yielded) without the scan going astray.
+
+// All yields every key in ascending order.
+func (t *Tree) All() iter.Seq[int] {
+	return keys(t.ops().ascend(func() *BstNode { return t.Root }, nil, nil))
+}
+
+// Backward yields every key in descending order.
+func (t *Tree) Backward() iter.Seq[int] {
+	return keys(t.ops().descend(func() *BstNode { return t.Root }))
+}
+
+// Range yields the keys in [lo, hi] in ascending order.
+func (t *Tree) Range(lo, hi int) iter.Seq[int] {
+	return keys(t.ops().ascend(func() *BstNode { return t.Root }, &lo, &hi))
+}
+
+// All yields every entry in ascending key order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return m.ops.ascend(func() *Node[K, V] { return m.root }, nil, nil)
+}
+
+// Backward yields every entry in descending key order.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return m.ops.descend(func() *Node[K, V] { return m.root })
+}
+
+// Range yields the entries with keys in [lo, hi] in ascending key order.
+func (m *Map[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return m.ops.ascend(func() *Node[K, V] { return m.root }, &lo, &hi)
+}
+
+func keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ascend yields entries with keys in [lo, hi] in ascending order; a nil bound
+// is unbounded.
+func (o ops[K, V]) ascend(root func() *Node[K, V], lo, hi *K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := o.after(root(), lo, true); n != nil; {
+			if hi != nil && o.cmp(n.Key, *hi) > 0 {
+				return
+			}
+			key := n.Key
+			if !yield(key, n.Value) {
+				return
+			}
+			n = o.after(root(), &key, false)
+		}
+	}
+}
+
+func (o ops[K, V]) descend(root func() *Node[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := o.before(root(), nil); n != nil; {
+			key := n.Key
+			if !yield(key, n.Value) {
+				return
+			}
+			n = o.before(root(), &key)
+		}
+	}
+}
+
+// after returns the node with the smallest key above key (or at key, if
+// inclusive). A nil key returns the minimum.
+func (o ops[K, V]) after(n *Node[K, V], key *K, inclusive bool) *Node[K, V] {
+	var best *Node[K, V]
+	for n != nil {
+		if key == nil {
+			best, n = n, n.Left
+			continue
+		}
+		c := o.cmp(n.Key, *key)
+		if c > 0 || (c == 0 && inclusive) {
+			best, n = n, n.Left
+		} else {
+			n = n.Right
+		}
+	}
+	return best
+}
+
+// before returns the node with the largest key strictly below key. A nil key
+// returns the maximum.
+func (o ops[K, V]) before(n *Node[K, V], key *K) *Node[K, V] {
+	var best *Node[K, V]
+	for n != nil {
+		if key == nil || o.cmp(n.Key, *key) < 0 {
+			best, n = n, n.Right
+		} else {
+			n = n.Left
+		}
+	}
+	return best
+}
@@ -0,0 +1,113 @@
+package bst
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTree_Iterators(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []int
+		lo, hi   int
+		all      []int
+		backward []int
+		rng      []int
+	}{
+		{"empty", nil, 0, 10, nil, nil, nil},
+		{"single_in_range", []int{5}, 0, 10, []int{5}, []int{5}, []int{5}},
+		{"single_out_of_range", []int{5}, 6, 10, []int{5}, []int{5}, nil},
+		{"many", []int{50, 20, 80, 10, 30, 70, 90}, 20, 70, []int{10, 20, 30, 50, 70, 80, 90}, []int{90, 80, 70, 50, 30, 20, 10}, []int{20, 30, 50, 70}},
+		{"bounds_between_keys", []int{10, 20, 30, 40}, 15, 35, []int{10, 20, 30, 40}, []int{40, 30, 20, 10}, []int{20, 30}},
+		{"inverted_range", []int{10, 20, 30}, 30, 10, []int{10, 20, 30}, []int{30, 20, 10}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, tree := range []*Tree{{}, NewBalanced()} {
+				for _, k := range tc.keys {
+					tree.Insert(k)
+				}
+				if got := slices.Collect(tree.All()); !reflect.DeepEqual(got, tc.all) {
+					t.Fatalf("All got %v, want %v", got, tc.all)
+				}
+				if got := slices.Collect(tree.Backward()); !reflect.DeepEqual(got, tc.backward) {
+					t.Fatalf("Backward got %v, want %v", got, tc.backward)
+				}
+				if got := slices.Collect(tree.Range(tc.lo, tc.hi)); !reflect.DeepEqual(got, tc.rng) {
+					t.Fatalf("Range(%d, %d) got %v, want %v", tc.lo, tc.hi, got, tc.rng)
+				}
+			}
+		})
+	}
+}
+
+func TestTree_Iterators_StopEarly(t *testing.T) {
+	tree := NewBalanced()
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i)
+	}
+
+	var got []int
+	for k := range tree.Range(10, 90) {
+		if k > 12 {
+			break
+		}
+		got = append(got, k)
+	}
+	if want := []int{10, 11, 12}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestTree_Iterators_DeleteMidScan(t *testing.T) {
+	tree := NewBalanced()
+	for i := 1; i <= 10; i++ {
+		tree.Insert(i)
+	}
+
+	// Delete the key just yielded and the one after it.
+	var got []int
+	for k := range tree.All() {
+		got = append(got, k)
+		if k%3 == 0 {
+			tree.Delete(k)
+			tree.Delete(k + 1)
+		}
+	}
+	if want := []int{1, 2, 3, 5, 6, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("scan got %v, want %v", got, want)
+	}
+	if want := []int{1, 2, 5, 8}; !reflect.DeepEqual(slices.Collect(tree.All()), want) {
+		t.Fatalf("after scan got %v, want %v", slices.Collect(tree.All()), want)
+	}
+
+	// Deleting behind a backward scan.
+	got = nil
+	for k := range tree.Backward() {
+		got = append(got, k)
+		tree.Delete(k)
+	}
+	if want := []int{8, 5, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("backward scan got %v, want %v", got, want)
+	}
+	if tree.Root != nil {
+		t.Fatalf("expected empty tree after deleting every key")
+	}
+}
+
+func TestMap_Range(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	for i, name := range []string{"a", "b", "c", "d"} {
+		m.Put((i+1)*100, name)
+	}
+
+	var got []string
+	for _, v := range m.Range(150, 400) {
+		got = append(got, v)
+	}
+	if want := []string{"b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Range got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package bst
+
+import "cmp"
+
+// Map is an ordered key/value map backed by an AVL tree. Keys are ordered by
+// the comparator passed to NewMap, which follows the cmp.Compare convention:
+// negative if a < b, zero if a == b, positive if a > b.
+type Map[K, V any] struct {
+	root *Node[K, V]
+	ops  ops[K, V]
+	size int
+}
+
+func NewMap[K, V any](compare func(a, b K) int) *Map[K, V] {
+	return &Map[K, V]{ops: ops[K, V]{cmp: compare, balanced: true}}
+}
+
+// NewOrderedMap returns a Map for keys with a natural ordering.
+func NewOrderedMap[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewMap[K, V](cmp.Compare[K])
+}
+
+func (m *Map[K, V]) Len() int {
+	return m.size
+}
+
+// Put sets the value for key, replacing any existing value.
+func (m *Map[K, V]) Put(key K, val V) {
+	var added bool
+	m.root, added = m.ops.insert(m.root, key, val)
+	if added {
+		m.size++
+	}
+}
+
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if n := m.ops.find(m.root, key); n != nil {
+		return n.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was present.
+func (m *Map[K, V]) Delete(key K) bool {
+	var removed bool
+	m.root, removed = m.ops.deleteNode(m.root, key)
+	if removed {
+		m.size--
+	}
+	return removed
+}
+
+func (m *Map[K, V]) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		return entry[K, V](nil)
+	}
+	for n.Left != nil {
+		n = n.Left
+	}
+	return entry(n)
+}
+
+func (m *Map[K, V]) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		return entry[K, V](nil)
+	}
+	for n.Right != nil {
+		n = n.Right
+	}
+	return entry(n)
+}
+
+// Floor returns the entry with the largest key <= key.
+func (m *Map[K, V]) Floor(key K) (K, V, bool) {
+	var best *Node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.ops.cmp(key, n.Key); {
+		case c < 0:
+			n = n.Left
+		case c > 0:
+			best = n
+			n = n.Right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(best)
+}
+
+// Ceiling returns the entry with the smallest key >= key.
+func (m *Map[K, V]) Ceiling(key K) (K, V, bool) {
+	var best *Node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.ops.cmp(key, n.Key); {
+		case c < 0:
+			best = n
+			n = n.Left
+		case c > 0:
+			n = n.Right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(best)
+}
+
+func (m *Map[K, V]) Height() int {
+	return height(m.root)
+}
+
+// Validate checks the ordering and AVL invariants of the underlying tree.
+func (m *Map[K, V]) Validate() error {
+	_, err := m.ops.validate(m.root, nil, nil)
+	return err
+}
+
+func entry[K, V any](n *Node[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.Key, n.Value, true
+}
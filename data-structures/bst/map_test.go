@@ -0,0 +1,119 @@
+package bst
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestMap_PutGetDelete(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+	m.Put("a", 10) // replace
+
+	if m.Len() != 3 {
+		t.Fatalf("Len got %d, want 3", m.Len())
+	}
+	if v, ok := m.Get("a"); !ok || v != 10 {
+		t.Fatalf("Get(a) got (%v, %v), want (10, true)", v, ok)
+	}
+	if _, ok := m.Get("z"); ok {
+		t.Fatalf("expected Get(z) to return ok=false")
+	}
+
+	if !m.Delete("b") {
+		t.Fatalf("expected Delete(b) to report removal")
+	}
+	if m.Delete("b") {
+		t.Fatalf("expected second Delete(b) to report nothing removed")
+	}
+	if _, ok := m.Get("b"); ok || m.Len() != 2 {
+		t.Fatalf("expected b to be gone and Len 2, got Len %d", m.Len())
+	}
+}
+
+func TestMap_MinMaxFloorCeiling(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	if _, _, ok := m.Min(); ok {
+		t.Fatalf("expected Min on empty map to return ok=false")
+	}
+	for _, k := range []int{10, 20, 30, 40} {
+		m.Put(k, "v")
+	}
+
+	if k, _, _ := m.Min(); k != 10 {
+		t.Fatalf("Min got %d, want 10", k)
+	}
+	if k, _, _ := m.Max(); k != 40 {
+		t.Fatalf("Max got %d, want 40", k)
+	}
+
+	tests := []struct {
+		name      string
+		key       int
+		floor     int
+		floorOK   bool
+		ceiling   int
+		ceilingOK bool
+	}{
+		{"below", 5, 0, false, 10, true},
+		{"exact", 20, 20, true, 20, true},
+		{"between", 25, 20, true, 30, true},
+		{"above", 45, 40, true, 0, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if k, _, ok := m.Floor(tc.key); k != tc.floor || ok != tc.floorOK {
+				t.Fatalf("Floor(%d) got (%d, %v), want (%d, %v)", tc.key, k, ok, tc.floor, tc.floorOK)
+			}
+			if k, _, ok := m.Ceiling(tc.key); k != tc.ceiling || ok != tc.ceilingOK {
+				t.Fatalf("Ceiling(%d) got (%d, %v), want (%d, %v)", tc.key, k, ok, tc.ceiling, tc.ceilingOK)
+			}
+		})
+	}
+}
+
+func TestMap_CustomComparator(t *testing.T) {
+	m := NewMap[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Put("Alice", 1)
+	m.Put("ALICE", 2)
+
+	if m.Len() != 1 {
+		t.Fatalf("expected case-insensitive keys to collapse, Len got %d", m.Len())
+	}
+	if v, ok := m.Get("alice"); !ok || v != 2 {
+		t.Fatalf("Get(alice) got (%v, %v), want (2, true)", v, ok)
+	}
+}
+
+func TestMap_RandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	m := NewOrderedMap[int, int]()
+	ref := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := r.IntN(300)
+		if r.IntN(3) == 0 {
+			m.Delete(key)
+			delete(ref, key)
+		} else {
+			m.Put(key, i)
+			ref[key] = i
+		}
+		if err := m.Validate(); err != nil {
+			t.Fatalf("op %d: %v", i, err)
+		}
+	}
+	if m.Len() != len(ref) {
+		t.Fatalf("Len got %d, want %d", m.Len(), len(ref))
+	}
+	for k, want := range ref {
+		if got, ok := m.Get(k); !ok || got != want {
+			t.Fatalf("Get(%d) got (%v, %v), want (%v, true)", k, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package bst
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Persistent is an immutable, AVL-balanced int-keyed BST. Insert and Delete
+// leave the receiver untouched and return a new version that shares every
+// subtree off the modified path with the old one, so each costs O(log n)
+// new nodes. Since no version is ever mutated, any number of goroutines can
+// read any version without locking. The zero value is an empty tree.
+type Persistent struct {
+	root *BstNode
+}
+
+var persistentOps = ops[int, struct{}]{cmp: cmp.Compare[int], balanced: true}
+
+func (p Persistent) Search(key int) bool {
+	return persistentOps.find(p.root, key) != nil
+}
+
+func (p Persistent) Insert(key int) Persistent {
+	return Persistent{root: pinsert(p.root, key)}
+}
+
+func (p Persistent) Delete(key int) Persistent {
+	return Persistent{root: pdelete(p.root, key)}
+}
+
+func (p Persistent) Len() int {
+	return size(p.root)
+}
+
+func (p Persistent) Height() int {
+	return height(p.root)
+}
+
+// All yields every key in ascending order.
+func (p Persistent) All() iter.Seq[int] {
+	return keys(persistentOps.ascend(func() *BstNode { return p.root }, nil, nil))
+}
+
+// Validate checks the BST ordering and AVL invariants of this version.
+func (p Persistent) Validate() error {
+	_, err := persistentOps.validate(p.root, nil, nil)
+	return err
+}
+
+// pinsert returns a copy of n with key added. Unchanged subtrees, including n
+// itself when key is already present, are shared rather than copied.
+func pinsert(n *BstNode, key int) *BstNode {
+	if n == nil {
+		return &BstNode{Key: key, height: 1, size: 1}
+	}
+	switch {
+	case key < n.Key:
+		l := pinsert(n.Left, key)
+		if l == n.Left {
+			return n
+		}
+		c := clone(n)
+		c.Left = l
+		return pfixup(c)
+	case key > n.Key:
+		r := pinsert(n.Right, key)
+		if r == n.Right {
+			return n
+		}
+		c := clone(n)
+		c.Right = r
+		return pfixup(c)
+	default:
+		return n
+	}
+}
+
+// pdelete returns a copy of n with key removed, sharing unchanged subtrees.
+func pdelete(n *BstNode, key int) *BstNode {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case key < n.Key:
+		l := pdelete(n.Left, key)
+		if l == n.Left {
+			return n
+		}
+		c := clone(n)
+		c.Left = l
+		return pfixup(c)
+	case key > n.Key:
+		r := pdelete(n.Right, key)
+		if r == n.Right {
+			return n
+		}
+		c := clone(n)
+		c.Right = r
+		return pfixup(c)
+	}
+
+	if n.Left == nil {
+		return n.Right
+	}
+	if n.Right == nil {
+		return n.Left
+	}
+
+	succ := n.Right
+	for succ.Left != nil {
+		succ = succ.Left
+	}
+	c := clone(n)
+	c.Key = succ.Key
+	c.Right = pdelete(n.Right, succ.Key)
+	return pfixup(c)
+}
+
+func clone(n *BstNode) *BstNode {
+	c := *n
+	return &c
+}
+
+// pfixup is fixup for a freshly copied node c. The rotations rewrite the
+// child that moves up (and, for double rotations, its child too), so those
+// are copied first; everything else stays shared.
+func pfixup(c *BstNode) *BstNode {
+	c.update()
+	switch b := c.balance(); {
+	case b > 1:
+		l := clone(c.Left)
+		if l.balance() < 0 {
+			l.Right = clone(l.Right)
+			l = persistentOps.rotateLeft(l)
+		}
+		c.Left = l
+		return persistentOps.rotateRight(c)
+	case b < -1:
+		r := clone(c.Right)
+		if r.balance() > 0 {
+			r.Left = clone(r.Left)
+			r = persistentOps.rotateRight(r)
+		}
+		c.Right = r
+		return persistentOps.rotateLeft(c)
+	}
+	return c
+}
@@ -0,0 +1,125 @@
+package bst
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistent_VersionsAreIndependent(t *testing.T) {
+	var v0 Persistent
+	v1 := v0.Insert(2).Insert(1).Insert(3)
+	v2 := v1.Insert(4)
+	v3 := v2.Delete(2)
+
+	tests := []struct {
+		name string
+		p    Persistent
+		want []int
+	}{
+		{"v0", v0, nil},
+		{"v1", v1, []int{1, 2, 3}},
+		{"v2", v2, []int{1, 2, 3, 4}},
+		{"v3", v3, []int{1, 3, 4}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tc.p.All()); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			if tc.p.Len() != len(tc.want) {
+				t.Fatalf("Len got %d, want %d", tc.p.Len(), len(tc.want))
+			}
+			if err := tc.p.Validate(); err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestPersistent_NoOpSharesRoot(t *testing.T) {
+	p := Persistent{}.Insert(1).Insert(2)
+	if p.Insert(2).root != p.root {
+		t.Fatalf("expected inserting an existing key to return the same version")
+	}
+	if p.Delete(3).root != p.root {
+		t.Fatalf("expected deleting a missing key to return the same version")
+	}
+}
+
+func TestPersistent_SnapshotsMatchHistory(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	var p Persistent
+	ref := make(map[int]bool)
+
+	var versions []Persistent
+	var want [][]int
+	for i := 0; i < 1000; i++ {
+		key := r.IntN(100)
+		if r.IntN(3) == 0 {
+			p = p.Delete(key)
+			delete(ref, key)
+		} else {
+			p = p.Insert(key)
+			ref[key] = true
+		}
+		if err := p.Validate(); err != nil {
+			t.Fatalf("op %d: %v", i, err)
+		}
+		if i%50 == 0 {
+			var keys []int
+			for k := range ref {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			versions = append(versions, p)
+			want = append(want, keys)
+		}
+	}
+
+	for i, v := range versions {
+		if got := slices.Collect(v.All()); !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("version %d got %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+// Run with -race: readers walk a snapshot while the writer keeps deriving new
+// versions from it.
+func TestPersistent_ConcurrentReaders(t *testing.T) {
+	var snap Persistent
+	for i := 0; i < 500; i++ {
+		snap = snap.Insert(i)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				n := 0
+				for range snap.All() {
+					n++
+				}
+				if n != 500 {
+					t.Errorf("reader saw %d keys, want 500", n)
+					return
+				}
+			}
+		}()
+	}
+
+	w := snap
+	for i := 0; i < 500; i += 2 {
+		w = w.Delete(i)
+		w = w.Insert(1000 + i)
+	}
+	wg.Wait()
+
+	if snap.Len() != 500 || w.Len() != 500 || w.Search(0) || !snap.Search(0) {
+		t.Fatalf("writer's versions leaked into the snapshot")
+	}
+}
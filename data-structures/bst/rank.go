@@ -0,0 +1,79 @@
+package bst
+
+// Order-statistic queries. Every node caches the size of its subtree, so
+// these run in O(height): O(log n) on a balanced tree.
+
+// Rank returns the number of keys strictly less than key.
+func (t *Tree) Rank(key int) int {
+	return t.ops().rank(t.Root, key, false)
+}
+
+// Select returns the i-th smallest key (0-based).
+func (t *Tree) Select(i int) (int, bool) {
+	n := selectNode(t.Root, i)
+	if n == nil {
+		return 0, false
+	}
+	return n.Key, true
+}
+
+// CountRange returns the number of keys in [lo, hi].
+func (t *Tree) CountRange(lo, hi int) int {
+	return t.ops().countRange(t.Root, lo, hi)
+}
+
+// Rank returns the number of keys strictly less than key.
+func (m *Map[K, V]) Rank(key K) int {
+	return m.ops.rank(m.root, key, false)
+}
+
+// Select returns the entry with the i-th smallest key (0-based).
+func (m *Map[K, V]) Select(i int) (K, V, bool) {
+	return entry(selectNode(m.root, i))
+}
+
+// CountRange returns the number of keys in [lo, hi].
+func (m *Map[K, V]) CountRange(lo, hi K) int {
+	return m.ops.countRange(m.root, lo, hi)
+}
+
+// rank counts the keys below key, or at or below it if inclusive.
+func (o ops[K, V]) rank(n *Node[K, V], key K, inclusive bool) int {
+	r := 0
+	for n != nil {
+		c := o.cmp(key, n.Key)
+		if c < 0 || (c == 0 && !inclusive) {
+			n = n.Left
+		} else {
+			r += size(n.Left) + 1
+			n = n.Right
+		}
+	}
+	return r
+}
+
+func (o ops[K, V]) countRange(n *Node[K, V], lo, hi K) int {
+	if o.cmp(lo, hi) > 0 {
+		return 0
+	}
+	return o.rank(n, hi, true) - o.rank(n, lo, false)
+}
+
+func selectNode[K, V any](n *Node[K, V], i int) *Node[K, V] {
+	if i < 0 || i >= size(n) {
+		return nil
+	}
+	for n != nil {
+		ls := size(n.Left)
+		switch {
+		case i < ls:
+			n = n.Left
+		case i > ls:
+			i -= ls + 1
+			n = n.Right
+		default:
+			return n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package bst
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestTree_RankSelect(t *testing.T) {
+	tree := NewBalanced()
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+		tree.Insert(k)
+	}
+
+	tests := []struct {
+		key  int
+		rank int
+	}{
+		{5, 0}, {10, 0}, {15, 1}, {50, 3}, {75, 5}, {90, 6}, {100, 7},
+	}
+	for _, tc := range tests {
+		if got := tree.Rank(tc.key); got != tc.rank {
+			t.Fatalf("Rank(%d) got %d, want %d", tc.key, got, tc.rank)
+		}
+	}
+
+	for i, want := range []int{10, 20, 30, 50, 70, 80, 90} {
+		if got, ok := tree.Select(i); !ok || got != want {
+			t.Fatalf("Select(%d) got (%d, %v), want (%d, true)", i, got, ok, want)
+		}
+	}
+	for _, i := range []int{-1, 7} {
+		if _, ok := tree.Select(i); ok {
+			t.Fatalf("expected Select(%d) to return ok=false", i)
+		}
+	}
+
+	if got := tree.CountRange(20, 70); got != 4 {
+		t.Fatalf("CountRange(20, 70) got %d, want 4", got)
+	}
+	if got := tree.CountRange(70, 20); got != 0 {
+		t.Fatalf("CountRange(70, 20) got %d, want 0", got)
+	}
+}
+
+func TestTree_RankSelect_RandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for _, tree := range []*Tree{{}, NewBalanced()} {
+		ref := make(map[int]bool)
+		for i := 0; i < 2000; i++ {
+			key := r.IntN(200)
+			if r.IntN(3) == 0 {
+				tree.Delete(key)
+				delete(ref, key)
+			} else {
+				tree.Insert(key)
+				ref[key] = true
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("op %d: %v", i, err)
+			}
+		}
+
+		sorted := make([]int, 0, len(ref))
+		for k := range ref {
+			sorted = append(sorted, k)
+		}
+		slices.Sort(sorted)
+
+		for i, k := range sorted {
+			if got, ok := tree.Select(i); !ok || got != k {
+				t.Fatalf("Select(%d) got (%d, %v), want (%d, true)", i, got, ok, k)
+			}
+			if got := tree.Rank(k); got != i {
+				t.Fatalf("Rank(%d) got %d, want %d", k, got, i)
+			}
+		}
+		lo, hi := 50, 150
+		want := 0
+		for _, k := range sorted {
+			if k >= lo && k <= hi {
+				want++
+			}
+		}
+		if got := tree.CountRange(lo, hi); got != want {
+			t.Fatalf("CountRange(%d, %d) got %d, want %d", lo, hi, got, want)
+		}
+	}
+}
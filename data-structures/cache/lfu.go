@@ -0,0 +1,144 @@
+package cache
+
+import (
+	dll "github.com/oneill-c/go-toy-problems/data-structures/doubly-linked-list"
+)
+
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	bucket *dll.DLNode[*freqBucket[K, V]]
+}
+
+// freqBucket holds every entry that has been used exactly freq times, most
+// recently used at the front.
+type freqBucket[K comparable, V any] struct {
+	freq    int
+	entries dll.DoublyLinkedList[*lfuEntry[K, V]]
+}
+
+// LFUCache is a fixed-capacity cache that evicts the least frequently used
+// entry, breaking ties by least recent use. Entries live in frequency
+// buckets, and the buckets themselves form a list in ascending frequency
+// order, so finding the victim and bumping an entry's count are both O(1).
+// It is not safe for concurrent use.
+type LFUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*dll.DLNode[*lfuEntry[K, V]]
+	buckets  dll.DoublyLinkedList[*freqBucket[K, V]]
+	onEvict  func(K, V)
+	stats    Stats
+}
+
+// NewLFUCache returns an empty cache holding at most capacity entries.
+// onEvict behaves as for NewLRUCache. NewLFUCache panics if capacity is not
+// positive.
+func NewLFUCache[K comparable, V any](capacity int, onEvict func(key K, value V)) *LFUCache[K, V] {
+	if capacity <= 0 {
+		panic("cache: capacity must be positive")
+	}
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*dll.DLNode[*lfuEntry[K, V]], capacity),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for key and counts it as a use.
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	n, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(n)
+	return n.Value.value, true
+}
+
+// Peek returns the value for key without counting a use or affecting stats.
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	if n, ok := c.items[key]; ok {
+		return n.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put sets the value for key. Updating an existing key counts as a use; a new
+// key evicts the least frequently used entry if the cache is full.
+func (c *LFUCache[K, V]) Put(key K, val V) {
+	if n, ok := c.items[key]; ok {
+		n.Value.value = val
+		c.touch(n)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+
+	b := c.buckets.Front()
+	if b == nil || b.Value.freq != 1 {
+		b = c.buckets.PushFront(&freqBucket[K, V]{freq: 1})
+	}
+	e := &lfuEntry[K, V]{key: key, value: val, bucket: b}
+	c.items[key] = b.Value.entries.PushFront(e)
+}
+
+// Remove deletes key and reports whether it was present.
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	n, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(n)
+	delete(c.items, key)
+	return true
+}
+
+func (c *LFUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+func (c *LFUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// touch moves n from its bucket to the front of the next frequency's bucket,
+// creating that bucket if needed.
+func (c *LFUCache[K, V]) touch(n *dll.DLNode[*lfuEntry[K, V]]) {
+	e := n.Value
+	cur := e.bucket
+	next := cur.Next()
+	if next == nil || next.Value.freq != cur.Value.freq+1 {
+		next = c.buckets.InsertAfter(&freqBucket[K, V]{freq: cur.Value.freq + 1}, cur)
+	}
+	c.unlink(n)
+	e.bucket = next
+	c.items[e.key] = next.Value.entries.PushFront(e)
+}
+
+// unlink removes n from its bucket, dropping the bucket once it is empty.
+func (c *LFUCache[K, V]) unlink(n *dll.DLNode[*lfuEntry[K, V]]) {
+	b := n.Value.bucket
+	b.Value.entries.Remove(n)
+	if b.Value.entries.Len() == 0 {
+		c.buckets.Remove(b)
+	}
+}
+
+func (c *LFUCache[K, V]) evict() {
+	b := c.buckets.Front()
+	if b == nil {
+		return
+	}
+	n := b.Value.entries.Back()
+	e := n.Value
+	c.unlink(n)
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLFUCache_EvictsLeastFrequent(t *testing.T) {
+	var evicted []string
+	c := NewLFUCache[string, int](3, func(k string, _ int) {
+		evicted = append(evicted, k)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+
+	c.Put("d", 4) // c has the fewest uses
+	c.Put("e", 5) // d has 1 use, b has 2
+	if !reflect.DeepEqual(evicted, []string{"c", "d"}) {
+		t.Fatalf("evicted got %v, want [c d]", evicted)
+	}
+}
+
+func TestLFUCache_TieBreaksByRecency(t *testing.T) {
+	c := NewLFUCache[int, int](2, nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(1)
+	c.Get(2) // both used twice; 1 is least recent
+	c.Put(3, 3)
+
+	if _, ok := c.Peek(1); ok {
+		t.Fatalf("expected 1 to be evicted")
+	}
+	if _, ok := c.Peek(2); !ok {
+		t.Fatalf("expected 2 to remain")
+	}
+}
+
+func TestLFUCache_RemoveAndStats(t *testing.T) {
+	c := NewLFUCache[int, string](2, nil)
+	c.Put(1, "one")
+	c.Put(2, "two")
+	c.Get(1)
+	c.Get(2)
+	c.Get(9)
+
+	// Removing the only low-frequency entry must not confuse eviction.
+	c.Put(3, "three") // evicts 1 (tie at 2 uses, 1 least recent)
+	if !c.Remove(3) {
+		t.Fatalf("expected Remove(3) to succeed")
+	}
+	c.Put(4, "four")
+	c.Put(5, "five") // evicts 4, the only entry with 1 use
+
+	if _, ok := c.Peek(4); ok {
+		t.Fatalf("expected 4 to be evicted")
+	}
+	if v, ok := c.Peek(2); !ok || v != "two" {
+		t.Fatalf("Peek(2) got (%q, %v), want (\"two\", true)", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", c.Len())
+	}
+
+	want := Stats{Hits: 2, Misses: 1, Evictions: 2}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	dll "github.com/oneill-c/go-toy-problems/data-structures/doubly-linked-list"
+)
+
+// Stats counts cache lookups and capacity evictions.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRUCache is a fixed-capacity cache that evicts the least recently used
+// entry. A map gives O(1) lookup of list nodes; the list keeps entries in
+// recency order, most recent at the front. It is not safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*dll.DLNode[entry[K, V]]
+	order    dll.DoublyLinkedList[entry[K, V]]
+	onEvict  func(K, V)
+	stats    Stats
+}
+
+// NewLRUCache returns an empty cache holding at most capacity entries.
+// onEvict, if non-nil, is called with each entry evicted to make room; it is
+// not called for entries removed with Remove. NewLRUCache panics if capacity
+// is not positive.
+func NewLRUCache[K comparable, V any](capacity int, onEvict func(key K, value V)) *LRUCache[K, V] {
+	if capacity <= 0 {
+		panic("cache: capacity must be positive")
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*dll.DLNode[entry[K, V]], capacity),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for key and marks it as most recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	n, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(n)
+	return n.Value.value, true
+}
+
+// Peek returns the value for key without affecting recency or stats.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	if n, ok := c.items[key]; ok {
+		return n.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put sets the value for key and marks it as most recently used, evicting the
+// least recently used entry if the cache is full.
+func (c *LRUCache[K, V]) Put(key K, val V) {
+	if n, ok := c.items[key]; ok {
+		n.Value.value = val
+		c.order.MoveToFront(n)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+	c.items[key] = c.order.PushFront(entry[K, V]{key: key, value: val})
+}
+
+// Remove deletes key and reports whether it was present.
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	n, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(n)
+	delete(c.items, key)
+	return true
+}
+
+func (c *LRUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+func (c *LRUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *LRUCache[K, V]) evict() {
+	n := c.order.Back()
+	if n == nil {
+		return
+	}
+	e := c.order.Remove(n)
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLRUCache_Eviction(t *testing.T) {
+	var evicted []string
+	c := NewLRUCache[string, int](2, func(k string, _ int) {
+		evicted = append(evicted, k)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")    // a is now most recent
+	c.Put("c", 3) // evicts b
+
+	if _, ok := c.Peek("b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) got (%v, %v), want (1, true)", v, ok)
+	}
+
+	c.Peek("c")   // Peek doesn't refresh c
+	c.Put("d", 4) // evicts c
+	if !reflect.DeepEqual(evicted, []string{"b", "c"}) {
+		t.Fatalf("evicted got %v, want [b c]", evicted)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", c.Len())
+	}
+}
+
+func TestLRUCache_UpdateAndRemove(t *testing.T) {
+	c := NewLRUCache[int, string](2, nil)
+	c.Put(1, "one")
+	c.Put(2, "two")
+	c.Put(1, "uno") // update refreshes 1
+	c.Put(3, "three")
+
+	if v, ok := c.Peek(1); !ok || v != "uno" {
+		t.Fatalf("Peek(1) got (%q, %v), want (\"uno\", true)", v, ok)
+	}
+	if _, ok := c.Peek(2); ok {
+		t.Fatalf("expected 2 to be evicted")
+	}
+
+	if !c.Remove(1) || c.Remove(1) {
+		t.Fatalf("expected exactly one successful Remove")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len got %d, want 1", c.Len())
+	}
+}
+
+func TestLRUCache_Stats(t *testing.T) {
+	c := NewLRUCache[int, int](1, nil)
+	c.Put(1, 1)
+	c.Get(1)
+	c.Get(2)
+	c.Peek(1)
+	c.Put(2, 2)
+
+	want := Stats{Hits: 1, Misses: 1, Evictions: 1}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the TTL cache what time it is. Tests can supply a fake clock to
+// control expiry without sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time // zero means the entry never expires
+}
+
+// TTLCache is an LRU cache whose entries also expire after a per-entry TTL.
+// Expired entries are never returned; they are dropped lazily on lookup and
+// in bulk by DeleteExpired or a janitor started with StartJanitor. It is safe
+// for concurrent use.
+type TTLCache[K comparable, V any] struct {
+	mu    sync.RWMutex
+	lru   *LRUCache[K, ttlEntry[V]]
+	clock Clock
+}
+
+// NewTTLCache returns an empty cache holding at most capacity entries. A nil
+// clock uses the system clock. NewTTLCache panics if capacity is not
+// positive.
+func NewTTLCache[K comparable, V any](capacity int, clock Clock) *TTLCache[K, V] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &TTLCache[K, V]{
+		lru:   NewLRUCache[K, ttlEntry[V]](capacity, nil),
+		clock: clock,
+	}
+}
+
+// Set stores val under key for ttl. A ttl <= 0 means the entry never
+// expires, though it can still be evicted to make room.
+func (c *TTLCache[K, V]) Set(key K, val V, ttl time.Duration) {
+	e := ttlEntry[V]{value: val}
+	if ttl > 0 {
+		e.expiresAt = c.clock.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Put(key, e)
+}
+
+// Get returns the value for key if present and unexpired, marking it as most
+// recently used. Get takes the write lock because it reorders the LRU list.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.lru.Peek(key)
+	if ok && c.expired(e) {
+		c.lru.Remove(key)
+		ok = false
+	}
+	if !ok {
+		c.lru.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	e, _ = c.lru.Get(key)
+	return e.value, true
+}
+
+// Peek returns the value for key if present and unexpired, without affecting
+// recency or stats.
+func (c *TTLCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.lru.Peek(key)
+	if !ok || c.expired(e) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Delete removes key and reports whether it was present.
+func (c *TTLCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Remove(key)
+}
+
+// Len returns the number of stored entries, including expired ones that have
+// not been dropped yet.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lru.Len()
+}
+
+func (c *TTLCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lru.Stats()
+}
+
+// DeleteExpired drops every expired entry and returns how many it dropped.
+func (c *TTLCache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for e := range c.lru.order.All() {
+		if c.expired(e.value) {
+			c.lru.Remove(e.key)
+			n++
+		}
+	}
+	return n
+}
+
+// StartJanitor starts a goroutine that calls DeleteExpired every interval
+// until ctx is canceled. The returned channel is closed once it has stopped.
+func (c *TTLCache[K, V]) StartJanitor(ctx context.Context, interval time.Duration) <-chan struct{} {
+	t := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer t.Stop()
+		c.janitor(ctx, t.C)
+		close(done)
+	}()
+	return done
+}
+
+func (c *TTLCache[K, V]) janitor(ctx context.Context, tick <-chan time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			c.DeleteExpired()
+		}
+	}
+}
+
+func (c *TTLCache[K, V]) expired(e ttlEntry[V]) bool {
+	return !e.expiresAt.IsZero() && !c.clock.Now().Before(e.expiresAt)
+}
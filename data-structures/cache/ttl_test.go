@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTTLCache_Expiry(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewTTLCache[string, int](10, clock)
+
+	c.Set("short", 1, time.Second)
+	c.Set("long", 2, time.Minute)
+	c.Set("forever", 3, 0)
+
+	clock.Advance(999 * time.Millisecond)
+	if v, ok := c.Get("short"); !ok || v != 1 {
+		t.Fatalf("Get(short) got (%v, %v), want (1, true)", v, ok)
+	}
+
+	clock.Advance(time.Millisecond)
+	if _, ok := c.Peek("short"); ok {
+		t.Fatalf("expected Peek(short) to miss once expired")
+	}
+	if _, ok := c.Get("short"); ok {
+		t.Fatalf("expected Get(short) to miss once expired")
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected expired entry to be dropped on Get, Len got %d", c.Len())
+	}
+
+	clock.Advance(time.Hour)
+	if n := c.DeleteExpired(); n != 1 {
+		t.Fatalf("DeleteExpired got %d, want 1", n)
+	}
+	if v, ok := c.Get("forever"); !ok || v != 3 {
+		t.Fatalf("Get(forever) got (%v, %v), want (3, true)", v, ok)
+	}
+
+	want := Stats{Hits: 2, Misses: 1}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats got %+v, want %+v", got, want)
+	}
+}
+
+func TestTTLCache_Janitor(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewTTLCache[int, int](10, clock)
+	for i := range 5 {
+		c.Set(i, i, time.Duration(i+1)*time.Second)
+	}
+	clock.Advance(3 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tick := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		c.janitor(ctx, tick)
+		close(done)
+	}()
+
+	tick <- clock.Now()
+	cancel()
+	<-done
+
+	if c.Len() != 2 {
+		t.Fatalf("expected janitor to drop 3 expired entries, Len got %d", c.Len())
+	}
+}
+
+func TestTTLCache_StartJanitorStopsOnCancel(t *testing.T) {
+	c := NewTTLCache[int, int](1, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := c.StartJanitor(ctx, time.Hour)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("janitor did not stop after cancel")
+	}
+}
+
+// Run with -race.
+func TestTTLCache_Concurrent(t *testing.T) {
+	c := NewTTLCache[string, int](64, nil)
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := fmt.Sprintf("k%d", (w*i)%100)
+				c.Set(key, i, time.Minute)
+				c.Get(key)
+				c.Peek(key)
+				if i%100 == 0 {
+					c.DeleteExpired()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.Len() > 64 {
+		t.Fatalf("Len got %d, exceeds capacity 64", c.Len())
+	}
+}
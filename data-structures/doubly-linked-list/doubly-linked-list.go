@@ -1,70 +1,188 @@
 package datastructures
 
-import "fmt"
+import "iter"
 
-type DLNode struct {
-	Value int
-	Prev  *DLNode
-	Next  *DLNode
+// DLNode is a handle to an element of a DoublyLinkedList. Handles stay valid
+// until the element is removed, so callers can keep them (e.g. in a map) and
+// later remove or move the element in O(1).
+type DLNode[T any] struct {
+	Value T
+	prev  *DLNode[T]
+	next  *DLNode[T]
+	list  *DoublyLinkedList[T]
 }
 
-type DoublyLinkedList struct {
-	Head *DLNode
-	Tail *DLNode
+func (n *DLNode[T]) Next() *DLNode[T] {
+	return n.next
 }
 
-func (l *DoublyLinkedList) Append(v int) {
-	n := &DLNode{Value: v}
-	if l.Head == nil {
-		l.Head = n
-		l.Tail = n
+func (n *DLNode[T]) Prev() *DLNode[T] {
+	return n.prev
+}
+
+// DoublyLinkedList is a generic doubly linked list. The zero value is an
+// empty list ready to use.
+type DoublyLinkedList[T any] struct {
+	head *DLNode[T]
+	tail *DLNode[T]
+	len  int
+}
+
+func (l *DoublyLinkedList[T]) Len() int {
+	return l.len
+}
+
+func (l *DoublyLinkedList[T]) Front() *DLNode[T] {
+	return l.head
+}
+
+func (l *DoublyLinkedList[T]) Back() *DLNode[T] {
+	return l.tail
+}
+
+func (l *DoublyLinkedList[T]) PushFront(v T) *DLNode[T] {
+	n := &DLNode[T]{Value: v}
+	l.linkBefore(n, l.head)
+	return n
+}
+
+func (l *DoublyLinkedList[T]) PushBack(v T) *DLNode[T] {
+	n := &DLNode[T]{Value: v}
+	l.linkAfter(n, l.tail)
+	return n
+}
+
+// InsertBefore inserts v immediately before mark. It returns nil if mark is
+// not an element of l.
+func (l *DoublyLinkedList[T]) InsertBefore(v T, mark *DLNode[T]) *DLNode[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	n := &DLNode[T]{Value: v}
+	l.linkBefore(n, mark)
+	return n
+}
+
+// InsertAfter inserts v immediately after mark. It returns nil if mark is not
+// an element of l.
+func (l *DoublyLinkedList[T]) InsertAfter(v T, mark *DLNode[T]) *DLNode[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	n := &DLNode[T]{Value: v}
+	l.linkAfter(n, mark)
+	return n
+}
+
+// Remove unlinks n from l and returns its value. It is a no-op if n is not
+// an element of l.
+func (l *DoublyLinkedList[T]) Remove(n *DLNode[T]) T {
+	if n.list == l {
+		l.unlink(n)
+	}
+	return n.Value
+}
+
+func (l *DoublyLinkedList[T]) MoveToFront(n *DLNode[T]) {
+	if n.list != l || l.head == n {
 		return
 	}
-	n.Prev = l.Tail
-	l.Tail.Next = n
-	l.Tail = n
+	l.unlink(n)
+	l.linkBefore(n, l.head)
 }
 
-func (l *DoublyLinkedList) Prepend(v int) {
-	n := &DLNode{Value: v}
-	if l.Head == nil {
-		l.Head = n
-		l.Tail = n
+func (l *DoublyLinkedList[T]) MoveToBack(n *DLNode[T]) {
+	if n.list != l || l.tail == n {
 		return
 	}
-	n.Next = l.Head
-	l.Head.Prev = n
-	l.Head = n
-}
-
-func (l *DoublyLinkedList) Delete(v int) {
-	for curr := l.Head; curr != nil; curr = curr.Next {
-		if curr.Value == v {
-			if curr.Prev != nil {
-				curr.Prev.Next = curr.Next
-			} else {
-				l.Head = curr.Next
+	l.unlink(n)
+	l.linkAfter(n, l.tail)
+}
+
+// All yields values from front to back. The element just yielded may be
+// removed during iteration.
+func (l *DoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; {
+			next := n.next
+			if !yield(n.Value) {
+				return
 			}
-			if curr.Next != nil {
-				curr.Next.Prev = curr.Prev
-			} else {
-				l.Tail = curr.Prev
+			n = next
+		}
+	}
+}
+
+// Backward yields values from back to front. The element just yielded may be
+// removed during iteration.
+func (l *DoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.tail; n != nil; {
+			prev := n.prev
+			if !yield(n.Value) {
+				return
 			}
-			return
+			n = prev
 		}
 	}
 }
 
-func (l *DoublyLinkedList) PrintForward() {
-	for n := l.Head; n != nil; n = n.Next {
-		fmt.Printf("%d ", n.Value)
+// linkBefore links n in front of at; a nil at means the back of the list.
+func (l *DoublyLinkedList[T]) linkBefore(n, at *DLNode[T]) {
+	if at == nil {
+		l.linkAfter(n, l.tail)
+		return
+	}
+	n.list = l
+	n.next = at
+	n.prev = at.prev
+	if at.prev != nil {
+		at.prev.next = n
+	} else {
+		l.head = n
 	}
-	fmt.Println()
+	at.prev = n
+	l.len++
 }
 
-func (l *DoublyLinkedList) PrintBackward() {
-	for n := l.Tail; n != nil; n = n.Prev {
-		fmt.Printf("%d ", n.Value)
+// linkAfter links n behind at; a nil at means the front of the list.
+func (l *DoublyLinkedList[T]) linkAfter(n, at *DLNode[T]) {
+	if at == nil {
+		n.list = l
+		n.prev = nil
+		n.next = l.head
+		if l.head != nil {
+			l.head.prev = n
+		} else {
+			l.tail = n
+		}
+		l.head = n
+		l.len++
+		return
+	}
+	n.list = l
+	n.prev = at
+	n.next = at.next
+	if at.next != nil {
+		at.next.prev = n
+	} else {
+		l.tail = n
+	}
+	at.next = n
+	l.len++
+}
+
+func (l *DoublyLinkedList[T]) unlink(n *DLNode[T]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.tail = n.prev
 	}
-	fmt.Println()
+	n.prev, n.next, n.list = nil, nil, nil
+	l.len--
 }
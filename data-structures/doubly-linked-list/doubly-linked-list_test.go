@@ -0,0 +1,108 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+)
+
+func assertList[T comparable](t *testing.T, l *DoublyLinkedList[T], want []T) {
+	t.Helper()
+	if got := slices.Collect(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("All got %v, want %v", got, want)
+	}
+	backward := slices.Clone(want)
+	slices.Reverse(backward)
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, backward) {
+		t.Fatalf("Backward got %v, want %v", got, backward)
+	}
+	if l.Len() != len(want) {
+		t.Fatalf("Len got %d, want %d", l.Len(), len(want))
+	}
+}
+
+func TestDoublyLinkedList_PushAndInsert(t *testing.T) {
+	var l DoublyLinkedList[int]
+	assertList(t, &l, nil)
+
+	two := l.PushBack(2)
+	l.PushFront(1)
+	four := l.PushBack(4)
+	l.InsertAfter(3, two)
+	l.InsertBefore(0, l.Front())
+	l.InsertAfter(5, four)
+
+	assertList(t, &l, []int{0, 1, 2, 3, 4, 5})
+	if l.Front().Value != 0 || l.Back().Value != 5 {
+		t.Fatalf("Front/Back got (%d, %d), want (0, 5)", l.Front().Value, l.Back().Value)
+	}
+}
+
+func TestDoublyLinkedList_RemoveAndMove(t *testing.T) {
+	var l DoublyLinkedList[string]
+	a := l.PushBack("a")
+	b := l.PushBack("b")
+	c := l.PushBack("c")
+
+	l.MoveToFront(c)
+	assertList(t, &l, []string{"c", "a", "b"})
+
+	l.MoveToBack(c)
+	assertList(t, &l, []string{"a", "b", "c"})
+
+	if v := l.Remove(b); v != "b" {
+		t.Fatalf("Remove got %q, want \"b\"", v)
+	}
+	assertList(t, &l, []string{"a", "c"})
+
+	// Removing twice, or operating on a removed node, is a no-op.
+	l.Remove(b)
+	l.MoveToFront(b)
+	if l.InsertAfter("x", b) != nil {
+		t.Fatalf("expected InsertAfter on a removed node to return nil")
+	}
+	assertList(t, &l, []string{"a", "c"})
+
+	l.Remove(a)
+	l.Remove(c)
+	assertList(t, &l, nil)
+	if l.Front() != nil || l.Back() != nil {
+		t.Fatalf("expected empty list to have no Front/Back")
+	}
+}
+
+func TestDoublyLinkedList_ForeignNode(t *testing.T) {
+	var l1, l2 DoublyLinkedList[int]
+	n := l1.PushBack(1)
+	l2.PushBack(2)
+
+	l2.Remove(n)
+	l2.MoveToFront(n)
+	assertList(t, &l1, []int{1})
+	assertList(t, &l2, []int{2})
+}
+
+func TestDoublyLinkedList_RemoveDuringIteration(t *testing.T) {
+	var l DoublyLinkedList[int]
+	nodes := map[int]*DLNode[int]{}
+	for i := 1; i <= 6; i++ {
+		nodes[i] = l.PushBack(i)
+	}
+
+	for v := range l.All() {
+		if v%2 == 0 {
+			l.Remove(nodes[v])
+		}
+	}
+	assertList(t, &l, []int{1, 3, 5})
+
+	var got []int
+	for v := range l.Backward() {
+		if v == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{5}) {
+		t.Fatalf("expected Backward to stop early, got %v", got)
+	}
+}
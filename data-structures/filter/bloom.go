@@ -0,0 +1,144 @@
+// Package filter provides probabilistic set membership: structures that
+// answer "have I seen this key?" in a fixed, small amount of memory, at the
+// price of occasional false positives. They never give false negatives, so
+// they suit dedupe paths that can afford an exact check (or a wrongly
+// skipped item) on the rare "maybe".
+package filter
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+
+	set "github.com/oneill-c/go-toy-problems/data-structures/set"
+)
+
+var errMalformed = errors.New("filter: malformed binary data")
+
+// hashes returns two independent 64-bit hashes of key, from FNV-1a and
+// FNV-1, for double hashing. h2 is forced odd so its multiples cycle
+// through every residue when the table size is a power of two.
+func hashes(key []byte) (h1, h2 uint64) {
+	a := fnv.New64a()
+	a.Write(key)
+	b := fnv.New64()
+	b.Write(key)
+	return mix(a.Sum64()), mix(b.Sum64()) | 1
+}
+
+// mix is the MurmurHash3 64-bit finalizer. FNV's last multiply barely
+// reaches the high bits, so keys differing only in their final byte (like
+// "tx-1" and "tx-2") would share them; mixing spreads every input bit over
+// the whole word.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// Bloom is a Bloom filter: k hash functions each set one bit of an m-bit
+// array per key, and a key is reported present if all of its k bits are
+// set. Keys can't be removed; see Cuckoo for that.
+type Bloom struct {
+	bits *set.BitSet
+	m    uint64 // number of bits
+	k    uint64 // number of hash functions
+	n    uint64 // number of keys added
+}
+
+// NewBloom returns a filter sized to hold n keys with a false-positive rate
+// of about p. It panics unless n > 0 and 0 < p < 1.
+func NewBloom(n int, p float64) *Bloom {
+	if n <= 0 || p <= 0 || p >= 1 {
+		panic("filter: NewBloom needs n > 0 and 0 < p < 1")
+	}
+	// The optimal sizes: m = -n ln p / (ln 2)^2 and k = (m/n) ln 2.
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+	return &Bloom{bits: set.NewBitSet(int(m)), m: uint64(m), k: uint64(k)}
+}
+
+// Add records key. The i-th of the k bit positions is h1 + i*h2 mod m
+// (Kirsch–Mitzenmacher double hashing), which behaves like k independent
+// hashes for the price of two.
+func (f *Bloom) Add(key []byte) {
+	h1, h2 := hashes(key)
+	for i := range f.k {
+		f.bits.Set(int((h1 + i*h2) % f.m))
+	}
+	f.n++
+}
+
+// Contains reports whether key may have been added. false is definite; true
+// is wrong with probability about FalsePositiveRate.
+func (f *Bloom) Contains(key []byte) bool {
+	h1, h2 := hashes(key)
+	for i := range f.k {
+		if !f.bits.Test(int((h1 + i*h2) % f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Bloom) AddString(key string) {
+	f.Add([]byte(key))
+}
+
+func (f *Bloom) ContainsString(key string) bool {
+	return f.Contains([]byte(key))
+}
+
+// Len returns the number of Add calls, counting repeated keys each time.
+func (f *Bloom) Len() int {
+	return int(f.n)
+}
+
+// FalsePositiveRate estimates the current false-positive rate from the
+// fraction of bits set. It rises above the target once more than the sized
+// number of keys have been added.
+func (f *Bloom) FalsePositiveRate() float64 {
+	return math.Pow(float64(f.bits.Count())/float64(f.m), float64(f.k))
+}
+
+// MarshalBinary encodes m, k and the key count as uvarints, followed by the
+// bit array.
+func (f *Bloom) MarshalBinary() ([]byte, error) {
+	bits, err := f.bits.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, 3*binary.MaxVarintLen64+len(bits))
+	out = binary.AppendUvarint(out, f.m)
+	out = binary.AppendUvarint(out, f.k)
+	out = binary.AppendUvarint(out, f.n)
+	return append(out, bits...), nil
+}
+
+func (f *Bloom) UnmarshalBinary(data []byte) error {
+	var hdr [3]uint64
+	for i := range hdr {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errMalformed
+		}
+		hdr[i], data = v, data[n:]
+	}
+	var bits set.BitSet
+	if err := bits.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	m, k := hdr[0], hdr[1]
+	if m == 0 || k == 0 || m > math.MaxInt {
+		return errMalformed
+	}
+	if _, ok := bits.NextSet(int(m)); ok {
+		return errMalformed // a bit set beyond the array
+	}
+	f.bits, f.m, f.k, f.n = &bits, m, k, hdr[2]
+	return nil
+}
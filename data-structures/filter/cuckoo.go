@@ -0,0 +1,212 @@
+package filter
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+const (
+	bucketSize = 4
+	// maxKicks bounds how many fingerprints Insert relocates before it
+	// declares the filter full.
+	maxKicks = 500
+	// A filter with 4-slot buckets fills to about 95% before inserts start
+	// failing, so size for a little under that.
+	cuckooLoad = 0.9
+)
+
+type bucket [bucketSize]uint16 // 0 marks an empty slot
+
+// Cuckoo is a cuckoo filter (Fan et al., 2014). It stores a 16-bit
+// fingerprint of each key in one of two candidate buckets, moving
+// fingerprints between their buckets to make room, as in cuckoo hashing.
+// Unlike a Bloom filter it supports Delete, and at this fingerprint size
+// it has a false-positive rate of about 0.01%.
+//
+// Delete must only be called for keys that were inserted, or it may remove
+// another key's matching fingerprint. Inserting the same key more than
+// 2*bucketSize times fills both its buckets and fails.
+type Cuckoo struct {
+	buckets []bucket
+	mask    uint64
+	count   int
+	rng     *rand.Rand
+}
+
+// NewCuckoo returns a filter with room for about capacity keys.
+func NewCuckoo(capacity int) *Cuckoo {
+	n := uint64(1)
+	for float64(n*bucketSize)*cuckooLoad < float64(capacity) {
+		n <<= 1
+	}
+	return newCuckoo(n)
+}
+
+func newCuckoo(buckets uint64) *Cuckoo {
+	return &Cuckoo{
+		buckets: make([]bucket, buckets),
+		mask:    buckets - 1,
+		rng:     rand.New(rand.NewPCG(buckets, 0x9e3779b97f4a7c15)),
+	}
+}
+
+// locate returns key's fingerprint and its two candidate buckets.
+func (f *Cuckoo) locate(key []byte) (fp uint16, i1, i2 uint64) {
+	h, _ := hashes(key)
+	fp = uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	i1 = h & f.mask
+	return fp, i1, f.alt(i1, fp)
+}
+
+// alt returns the other bucket for a fingerprint in bucket i. Hashing the
+// fingerprint, rather than the key, is what lets Insert move fingerprints
+// without the original keys; XOR makes alt its own inverse.
+func (f *Cuckoo) alt(i uint64, fp uint16) uint64 {
+	_, h := hashes([]byte{byte(fp), byte(fp >> 8)})
+	return (i ^ h) & f.mask
+}
+
+func (b *bucket) insert(fp uint16) bool {
+	for s := range b {
+		if b[s] == 0 {
+			b[s] = fp
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) has(fp uint16) bool {
+	for _, v := range b {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) remove(fp uint16) bool {
+	for s := range b {
+		if b[s] == fp {
+			b[s] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Insert adds key, reporting false if the filter is too full to place it.
+// A failed Insert leaves the filter as it was.
+func (f *Cuckoo) Insert(key []byte) bool {
+	fp, i1, i2 := f.locate(key)
+	if f.buckets[i1].insert(fp) || f.buckets[i2].insert(fp) {
+		f.count++
+		return true
+	}
+
+	// Both buckets are full: evict a random fingerprint to its other
+	// bucket, and so on down the chain, remembering each swap so a chain
+	// that doesn't end in a free slot can be undone.
+	type swap struct {
+		i    uint64
+		slot int
+	}
+	path := make([]swap, 0, maxKicks)
+	i := i1
+	if f.rng.IntN(2) == 0 {
+		i = i2
+	}
+	for range maxKicks {
+		s := f.rng.IntN(bucketSize)
+		fp, f.buckets[i][s] = f.buckets[i][s], fp
+		path = append(path, swap{i, s})
+		i = f.alt(i, fp)
+		if f.buckets[i].insert(fp) {
+			f.count++
+			return true
+		}
+	}
+	for j := len(path) - 1; j >= 0; j-- {
+		sw := path[j]
+		fp, f.buckets[sw.i][sw.slot] = f.buckets[sw.i][sw.slot], fp
+	}
+	return false
+}
+
+// Contains reports whether key may have been inserted. false is definite.
+func (f *Cuckoo) Contains(key []byte) bool {
+	fp, i1, i2 := f.locate(key)
+	return f.buckets[i1].has(fp) || f.buckets[i2].has(fp)
+}
+
+// Delete removes one copy of key, reporting whether a matching fingerprint
+// was found.
+func (f *Cuckoo) Delete(key []byte) bool {
+	fp, i1, i2 := f.locate(key)
+	if f.buckets[i1].remove(fp) || f.buckets[i2].remove(fp) {
+		f.count--
+		return true
+	}
+	return false
+}
+
+func (f *Cuckoo) InsertString(key string) bool {
+	return f.Insert([]byte(key))
+}
+
+func (f *Cuckoo) ContainsString(key string) bool {
+	return f.Contains([]byte(key))
+}
+
+func (f *Cuckoo) DeleteString(key string) bool {
+	return f.Delete([]byte(key))
+}
+
+// Len returns the number of fingerprints stored.
+func (f *Cuckoo) Len() int {
+	return f.count
+}
+
+// LoadFactor returns the fraction of slots in use.
+func (f *Cuckoo) LoadFactor() float64 {
+	return float64(f.count) / float64(len(f.buckets)*bucketSize)
+}
+
+// MarshalBinary encodes the bucket count as a uvarint, followed by every
+// slot as a little-endian uint16.
+func (f *Cuckoo) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, binary.MaxVarintLen64+2*bucketSize*len(f.buckets))
+	out = binary.AppendUvarint(out, uint64(len(f.buckets)))
+	for _, b := range f.buckets {
+		for _, fp := range b {
+			out = binary.LittleEndian.AppendUint16(out, fp)
+		}
+	}
+	return out, nil
+}
+
+func (f *Cuckoo) UnmarshalBinary(data []byte) error {
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n == 0 || n&(n-1) != 0 {
+		return errMalformed // the bucket count must be a power of two
+	}
+	data = data[k:]
+	if n > uint64(len(data))/(2*bucketSize) || uint64(len(data)) != 2*bucketSize*n {
+		return errMalformed
+	}
+	g := newCuckoo(n)
+	for i := range g.buckets {
+		for s := range bucketSize {
+			fp := binary.LittleEndian.Uint16(data[2*(i*bucketSize+s):])
+			g.buckets[i][s] = fp
+			if fp != 0 {
+				g.count++
+			}
+		}
+	}
+	*f = *g
+	return nil
+}
@@ -0,0 +1,233 @@
+package filter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func key(prefix string, i int) []byte {
+	return []byte(fmt.Sprintf("%s-%d", prefix, i))
+}
+
+// falsePositiveRate probes with keys that were never added.
+func falsePositiveRate(contains func([]byte) bool, probes int) float64 {
+	fp := 0
+	for i := 0; i < probes; i++ {
+		if contains(key("absent", i)) {
+			fp++
+		}
+	}
+	return float64(fp) / float64(probes)
+}
+
+func TestBloom_NoFalseNegatives(t *testing.T) {
+	f := NewBloom(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add(key("tx", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.Contains(key("tx", i)) {
+			t.Fatalf("false negative for %s", key("tx", i))
+		}
+	}
+	f.AddString("batman@gmail.com")
+	if !f.ContainsString("batman@gmail.com") || f.Len() != 1001 {
+		t.Fatalf("expected string key to be found and Len 1001, got Len %d", f.Len())
+	}
+}
+
+func TestBloom_FalsePositiveRate(t *testing.T) {
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		t.Run(fmt.Sprint(p), func(t *testing.T) {
+			const n = 10000
+			f := NewBloom(n, p)
+			for i := 0; i < n; i++ {
+				f.Add(key("tx", i))
+			}
+			got := falsePositiveRate(f.Contains, 200000)
+			// Allow for sampling noise and rounding of m and k.
+			if got > 1.5*p {
+				t.Fatalf("measured FP rate %.5f, want about %v", got, p)
+			}
+			if est := f.FalsePositiveRate(); est > 1.5*p || est < p/1.5 {
+				t.Fatalf("estimated FP rate %.5f, want about %v", est, p)
+			}
+		})
+	}
+}
+
+func TestBloom_Binary(t *testing.T) {
+	f := NewBloom(500, 0.01)
+	for i := 0; i < 500; i++ {
+		f.Add(key("tx", i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var g Bloom
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if g.m != f.m || g.k != f.k || g.Len() != f.Len() {
+		t.Fatalf("got m=%d k=%d n=%d, want m=%d k=%d n=%d", g.m, g.k, g.Len(), f.m, f.k, f.Len())
+	}
+	for i := 0; i < 2000; i++ {
+		if f.Contains(key("tx", i)) != g.Contains(key("tx", i)) {
+			t.Fatalf("filters disagree on %s", key("tx", i))
+		}
+	}
+
+	for _, bad := range [][]byte{nil, {1}, {0, 1, 0, 0}, data[:len(data)-1]} {
+		if err := g.UnmarshalBinary(bad); err == nil {
+			t.Fatalf("expected UnmarshalBinary(%v) to fail", bad)
+		}
+	}
+}
+
+func TestNewBloom_PanicsOnBadArgs(t *testing.T) {
+	for _, args := range []struct {
+		n int
+		p float64
+	}{{0, 0.1}, {10, 0}, {10, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected NewBloom(%d, %v) to panic", args.n, args.p)
+				}
+			}()
+			NewBloom(args.n, args.p)
+		}()
+	}
+}
+
+func TestCuckoo_InsertContainsDelete(t *testing.T) {
+	f := NewCuckoo(1000)
+	for i := 0; i < 1000; i++ {
+		if !f.Insert(key("tx", i)) {
+			t.Fatalf("Insert %d failed below capacity", i)
+		}
+	}
+	if f.Len() != 1000 {
+		t.Fatalf("Len got %d, want 1000", f.Len())
+	}
+
+	for i := 0; i < 1000; i += 2 {
+		if !f.Delete(key("tx", i)) {
+			t.Fatalf("Delete(%s) found nothing", key("tx", i))
+		}
+	}
+	for i := 1; i < 1000; i += 2 {
+		if !f.Contains(key("tx", i)) {
+			t.Fatalf("false negative for %s after deletes", key("tx", i))
+		}
+	}
+	deleted := 0
+	for i := 0; i < 1000; i += 2 {
+		if !f.Contains(key("tx", i)) {
+			deleted++
+		}
+	}
+	if deleted < 495 {
+		t.Fatalf("only %d of 500 deleted keys are gone", deleted)
+	}
+	if f.Len() != 500 {
+		t.Fatalf("Len got %d, want 500", f.Len())
+	}
+
+	if !f.InsertString("x") || !f.ContainsString("x") || !f.DeleteString("x") {
+		t.Fatalf("string helpers failed")
+	}
+}
+
+func TestCuckoo_FalsePositiveRate(t *testing.T) {
+	const n = 10000
+	f := NewCuckoo(n)
+	for i := 0; i < n; i++ {
+		f.Insert(key("tx", i))
+	}
+	// Two buckets of four 16-bit fingerprints: at most 8/65536, lower at
+	// partial load.
+	if got := falsePositiveRate(f.Contains, 200000); got > 8.0/65536 {
+		t.Fatalf("measured FP rate %.6f, want under %.6f", got, 8.0/65536)
+	}
+}
+
+func TestCuckoo_FullFilterKeepsEverything(t *testing.T) {
+	f := NewCuckoo(100)
+	var inserted []int
+	for i := 0; ; i++ {
+		if !f.Insert(key("tx", i)) {
+			break
+		}
+		inserted = append(inserted, i)
+	}
+	if f.LoadFactor() < 0.8 {
+		t.Fatalf("filter reported full at load factor %.2f", f.LoadFactor())
+	}
+	// The failed Insert must not have evicted anything.
+	for _, i := range inserted {
+		if !f.Contains(key("tx", i)) {
+			t.Fatalf("false negative for %s after a failed Insert", key("tx", i))
+		}
+	}
+	if f.Len() != len(inserted) {
+		t.Fatalf("Len got %d, want %d", f.Len(), len(inserted))
+	}
+}
+
+func TestCuckoo_Binary(t *testing.T) {
+	f := NewCuckoo(500)
+	for i := 0; i < 500; i++ {
+		f.Insert(key("tx", i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var g Cuckoo
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if g.Len() != f.Len() {
+		t.Fatalf("Len got %d, want %d", g.Len(), f.Len())
+	}
+	for i := 0; i < 500; i++ {
+		if !g.Contains(key("tx", i)) {
+			t.Fatalf("false negative for %s after round trip", key("tx", i))
+		}
+	}
+	if !g.Delete(key("tx", 0)) || !g.Insert(key("tx", 0)) {
+		t.Fatalf("expected decoded filter to be usable")
+	}
+
+	for _, bad := range [][]byte{nil, {3, 0, 0}, data[:len(data)-1]} {
+		if err := g.UnmarshalBinary(bad); err == nil {
+			t.Fatalf("expected UnmarshalBinary(%v) to fail", bad)
+		}
+	}
+}
+
+func BenchmarkFilters(b *testing.B) {
+	const n = 100000
+	bloom := NewBloom(n, 0.01)
+	cuckoo := NewCuckoo(n)
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = key("tx", i)
+		bloom.Add(keys[i])
+		cuckoo.Insert(keys[i])
+	}
+	b.Run("Bloom/Contains", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bloom.Contains(keys[i%n])
+		}
+	})
+	b.Run("Cuckoo/Contains", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cuckoo.Contains(keys[i%n])
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package heap
+
+import "cmp"
+
+// Heap is a binary heap ordered by a less function: the element for which
+// less holds against every other is at the top. It replaces hand-written
+// container/heap.Interface implementations with a single generic type.
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+	// moved, if set, is told an element's index whenever it is placed or
+	// moves, so a wrapper like IndexedPQ can find elements by key.
+	moved func(v T, i int)
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// From builds a heap from items in O(n). The heap takes ownership of items.
+func From[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: items, less: less}
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// NewMinHeap returns an empty heap with the smallest element on top.
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// NewMaxHeap returns an empty heap with the largest element on top.
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+func (h *Heap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.placed(len(h.data) - 1)
+	h.up(len(h.data) - 1)
+}
+
+// Pop removes and returns the top element.
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Remove(0), true
+}
+
+// Peek returns the top element without removing it.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// At returns the element at index i of the heap's backing array, for use
+// with Fix and Remove.
+func (h *Heap[T]) At(i int) T {
+	return h.data[i]
+}
+
+// Set replaces the element at index i and restores the heap order.
+func (h *Heap[T]) Set(i int, v T) {
+	h.data[i] = v
+	h.placed(i)
+	h.Fix(i)
+}
+
+// Fix restores the heap order after the element at index i has changed.
+func (h *Heap[T]) Fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// Remove removes and returns the element at index i.
+func (h *Heap[T]) Remove(i int) T {
+	n := len(h.data) - 1
+	if i != n {
+		h.swap(i, n)
+	}
+	v := h.data[n]
+	var zero T
+	h.data[n] = zero // don't keep a reference for the GC
+	h.data = h.data[:n]
+	if i != n {
+		h.Fix(i)
+	}
+	return v
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.placed(i)
+	h.placed(j)
+}
+
+func (h *Heap[T]) placed(i int) {
+	if h.moved != nil {
+		h.moved(h.data[i], i)
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the element at i towards the leaves and reports whether it moved.
+func (h *Heap[T]) down(i int) bool {
+	start, n := i, len(h.data)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if r := child + 1; r < n && h.less(h.data[r], h.data[child]) {
+			child = r
+		}
+		if !h.less(h.data[child], h.data[i]) {
+			break
+		}
+		h.swap(i, child)
+		i = child
+	}
+	return i > start
+}
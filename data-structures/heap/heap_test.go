@@ -0,0 +1,94 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func drain[T any](h *Heap[T]) []T {
+	var out []T
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestHeap_MinMax(t *testing.T) {
+	minH := NewMinHeap[int]()
+	maxH := NewMaxHeap[int]()
+	for _, v := range []int{5, 1, 4, 3, 2} {
+		minH.Push(v)
+		maxH.Push(v)
+	}
+
+	if top, ok := minH.Peek(); !ok || top != 1 {
+		t.Fatalf("Peek got (%v, %v), want (1, true)", top, ok)
+	}
+	if got := drain(minH); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("min heap drained %v", got)
+	}
+	if got := drain(maxH); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("max heap drained %v", got)
+	}
+
+	if _, ok := minH.Pop(); ok {
+		t.Fatalf("expected Pop on empty heap to return ok=false")
+	}
+	if _, ok := minH.Peek(); ok {
+		t.Fatalf("expected Peek on empty heap to return ok=false")
+	}
+}
+
+func TestHeap_FromAndFix(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	h := From([]job{{"a", 5}, {"b", 3}, {"c", 8}, {"d", 1}}, func(x, y job) bool {
+		return x.priority < y.priority
+	})
+
+	// Bump whichever job sits at index 2 to the front.
+	j := h.At(2)
+	j.priority = 0
+	h.Set(2, j)
+	if top, _ := h.Peek(); top.name != j.name {
+		t.Fatalf("Peek got %q, want %q", top.name, j.name)
+	}
+
+	removed := h.Remove(1)
+	var names []string
+	for _, j := range drain(h) {
+		if j.name == removed.name {
+			t.Fatalf("removed job %q still in heap", removed.name)
+		}
+		names = append(names, j.name)
+	}
+	if len(names) != 3 {
+		t.Fatalf("expected 3 jobs left, got %v", names)
+	}
+}
+
+func TestHeap_RandomAgainstSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 14))
+	items := make([]int, 500)
+	for i := range items {
+		items[i] = r.IntN(100)
+	}
+	want := slices.Sorted(slices.Values(items))
+
+	h := From(slices.Clone(items), func(a, b int) bool { return a < b })
+	if got := drain(h); !slices.Equal(got, want) {
+		t.Fatalf("From drained out of order")
+	}
+
+	h = NewMinHeap[int]()
+	for _, v := range items {
+		h.Push(v)
+	}
+	if got := drain(h); !slices.Equal(got, want) {
+		t.Fatalf("Push drained out of order")
+	}
+}
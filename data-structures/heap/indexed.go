@@ -0,0 +1,88 @@
+package heap
+
+type indexedItem[ID comparable, P any] struct {
+	id       ID
+	priority P
+}
+
+// IndexedPQ is a priority queue of unique IDs. It tracks where each ID sits
+// in the heap, so an ID's priority can be changed or the ID removed in
+// O(log n) — e.g. the decrease-key step in Dijkstra's algorithm, or
+// rescheduling a queued job.
+type IndexedPQ[ID comparable, P any] struct {
+	heap *Heap[indexedItem[ID, P]]
+	pos  map[ID]int // kept current by the heap's moved hook
+}
+
+// NewIndexedPQ returns an empty queue whose top is the ID with the priority
+// for which less holds against every other.
+func NewIndexedPQ[ID comparable, P any](less func(a, b P) bool) *IndexedPQ[ID, P] {
+	q := &IndexedPQ[ID, P]{pos: make(map[ID]int)}
+	q.heap = New(func(a, b indexedItem[ID, P]) bool { return less(a.priority, b.priority) })
+	q.heap.moved = func(it indexedItem[ID, P], i int) { q.pos[it.id] = i }
+	return q
+}
+
+func (q *IndexedPQ[ID, P]) Len() int {
+	return q.heap.Len()
+}
+
+func (q *IndexedPQ[ID, P]) Contains(id ID) bool {
+	_, ok := q.pos[id]
+	return ok
+}
+
+// Priority returns id's current priority.
+func (q *IndexedPQ[ID, P]) Priority(id ID) (P, bool) {
+	i, ok := q.pos[id]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return q.heap.At(i).priority, true
+}
+
+// Push adds id with priority p, or updates its priority if already queued.
+func (q *IndexedPQ[ID, P]) Push(id ID, p P) {
+	if q.Update(id, p) {
+		return
+	}
+	q.heap.Push(indexedItem[ID, P]{id: id, priority: p})
+}
+
+// Update changes the priority of a queued id. It reports false, and does
+// nothing, if id is not queued.
+func (q *IndexedPQ[ID, P]) Update(id ID, p P) bool {
+	i, ok := q.pos[id]
+	if !ok {
+		return false
+	}
+	q.heap.Set(i, indexedItem[ID, P]{id: id, priority: p})
+	return true
+}
+
+// Pop removes and returns the top ID and its priority.
+func (q *IndexedPQ[ID, P]) Pop() (ID, P, bool) {
+	it, ok := q.heap.Pop()
+	if ok {
+		delete(q.pos, it.id)
+	}
+	return it.id, it.priority, ok
+}
+
+// Peek returns the top ID and its priority without removing it.
+func (q *IndexedPQ[ID, P]) Peek() (ID, P, bool) {
+	it, ok := q.heap.Peek()
+	return it.id, it.priority, ok
+}
+
+// Remove drops id from the queue and reports whether it was queued.
+func (q *IndexedPQ[ID, P]) Remove(id ID) bool {
+	i, ok := q.pos[id]
+	if !ok {
+		return false
+	}
+	q.heap.Remove(i)
+	delete(q.pos, id)
+	return true
+}
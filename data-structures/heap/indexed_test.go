@@ -0,0 +1,86 @@
+package heap
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"testing"
+)
+
+func TestIndexedPQ_UpdateRemoveContains(t *testing.T) {
+	q := NewIndexedPQ[string, int](cmp.Less[int])
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	q.Push("d", 1)
+
+	if !q.Update("c", 0) {
+		t.Fatalf("expected Update(c) to succeed")
+	}
+	if q.Update("zzz", 0) {
+		t.Fatalf("expected Update of a missing id to fail")
+	}
+	q.Push("d", 10) // Push on a queued id updates it
+	if !q.Remove("b") || q.Remove("b") {
+		t.Fatalf("expected exactly one successful Remove(b)")
+	}
+	if q.Contains("b") || !q.Contains("a") {
+		t.Fatalf("Contains out of sync with queue")
+	}
+	if p, ok := q.Priority("d"); !ok || p != 10 {
+		t.Fatalf("Priority(d) got (%v, %v), want (10, true)", p, ok)
+	}
+
+	var got []string
+	for q.Len() > 0 {
+		id, _, _ := q.Pop()
+		got = append(got, id)
+	}
+	want := []string{"c", "a", "d"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pop order got %v, want %v", got, want)
+		}
+	}
+	if _, _, ok := q.Pop(); ok {
+		t.Fatalf("expected Pop on empty queue to return ok=false")
+	}
+}
+
+func dijkstra(graph map[string]map[string]int, src string) map[string]int {
+	dist := map[string]int{src: 0}
+	q := NewIndexedPQ[string, int](cmp.Less[int])
+	q.Push(src, 0)
+	for q.Len() > 0 {
+		u, d, _ := q.Pop()
+		for v, w := range graph[u] {
+			if nd := d + w; nd < distOr(dist, v) {
+				dist[v] = nd
+				q.Push(v, nd) // inserts or decreases the key
+			}
+		}
+	}
+	return dist
+}
+
+func distOr(dist map[string]int, v string) int {
+	if d, ok := dist[v]; ok {
+		return d
+	}
+	return math.MaxInt
+}
+
+func TestIndexedPQ_Dijkstra(t *testing.T) {
+	graph := map[string]map[string]int{
+		"a": {"b": 7, "c": 9, "f": 14},
+		"b": {"a": 7, "c": 10, "d": 15},
+		"c": {"a": 9, "b": 10, "d": 11, "f": 2},
+		"d": {"b": 15, "c": 11, "e": 6},
+		"e": {"d": 6, "f": 9},
+		"f": {"a": 14, "c": 2, "e": 9},
+	}
+	want := map[string]int{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}
+	if got := dijkstra(graph, "a"); !maps.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
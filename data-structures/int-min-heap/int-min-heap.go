@@ -1,50 +1,35 @@
 package intminheap
 
-import "container/heap"
+import (
+	"cmp"
+	"slices"
 
-type IntMinHeap []int
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
+)
 
-func (h IntMinHeap) Len() int {
-	return len(h)
-}
-
-func (h IntMinHeap) Less(i, j int) bool {
-	return h[i] < h[j]
-}
-
-func (h IntMinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
-}
-
-func (h *IntMinHeap) Push(x any) {
-	*h = append(*h, x.(int))
-}
+type IntMinHeap = heap.Heap[int]
 
-func (h *IntMinHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[:n-1]
-	return x
+func NewIntMinHeap(nums ...int) *IntMinHeap {
+	return heap.From(slices.Clone(nums), cmp.Less[int])
 }
 
 func TopKLargest(nums []int, k int) []int {
 	if k <= 0 {
 		return nil
 	}
-	h := &IntMinHeap{}
-	heap.Init(h)
+	h := NewIntMinHeap()
 	for _, x := range nums {
 		if h.Len() < k {
-			heap.Push(h, x)
-		} else if x > (*h)[0] {
-			heap.Pop(h)
-			heap.Push(h, x)
+			h.Push(x)
+		} else if top, _ := h.Peek(); x > top {
+			h.Pop()
+			h.Push(x)
 		}
 	}
 	out := make([]int, 0, h.Len())
 	for h.Len() > 0 {
-		out = append(out, heap.Pop(h).(int))
+		x, _ := h.Pop()
+		out = append(out, x)
 	}
 	return out
-}
\ No newline at end of file
+}
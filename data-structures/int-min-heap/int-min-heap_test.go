@@ -1,30 +1,29 @@
 package intminheap
 
 import (
-	"container/heap"
 	"reflect"
 	"testing"
 )
 
 func TestIntMinHeap_Order(t *testing.T) {
-	h := &IntMinHeap{5,1,4}
-	heap.Init(h)
-	heap.Push(h, 3)
+	h := NewIntMinHeap(5, 1, 4)
+	h.Push(3)
 	var got []int
 	for h.Len() > 0 {
-		got = append(got, heap.Pop(h).(int))
+		x, _ := h.Pop()
+		got = append(got, x)
 	}
-	want := []int{1,3,4,5}
+	want := []int{1, 3, 4, 5}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
 
 func TestTopLargest(t *testing.T) {
-	nums := []int{5,1,9,3,12,7,2}
+	nums := []int{5, 1, 9, 3, 12, 7, 2}
 	got := TopKLargest(nums, 3)
-	want := []int{7,9,12}
+	want := []int{7, 9, 12}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
-}
\ No newline at end of file
+}
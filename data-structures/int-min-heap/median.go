@@ -0,0 +1,172 @@
+package intminheap
+
+import "github.com/oneill-c/go-toy-problems/data-structures/heap"
+
+// MedianTracker maintains the running median of a stream of ints. The lower
+// half lives in a max-heap and the upper half in an IntMinHeap, with the
+// lower half holding the extra element when the count is odd, so the median
+// is always at one or both of the tops.
+type MedianTracker struct {
+	lo *heap.Heap[int]
+	hi *IntMinHeap
+}
+
+func NewMedianTracker() *MedianTracker {
+	return &MedianTracker{lo: heap.NewMaxHeap[int](), hi: NewIntMinHeap()}
+}
+
+func (m *MedianTracker) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+func (m *MedianTracker) Add(x int) {
+	if top, ok := m.lo.Peek(); !ok || x <= top {
+		m.lo.Push(x)
+	} else {
+		m.hi.Push(x)
+	}
+	if m.lo.Len() > m.hi.Len()+1 {
+		v, _ := m.lo.Pop()
+		m.hi.Push(v)
+	} else if m.hi.Len() > m.lo.Len() {
+		v, _ := m.hi.Pop()
+		m.lo.Push(v)
+	}
+}
+
+// Median returns the median of the values added so far; for an even count
+// it is the mean of the two middle values.
+func (m *MedianTracker) Median() (float64, bool) {
+	return median(m.lo, m.hi, m.lo.Len(), m.hi.Len())
+}
+
+// SlidingMedian is a MedianTracker that also supports removing values, e.g.
+// those that have fallen out of a time or count window. Removal is lazy: a
+// removed value is only counted as gone until it surfaces at the top of its
+// heap, where it is discarded. Add and Remove are O(log n) amortized.
+type SlidingMedian struct {
+	lo, hi         *heap.Heap[int]
+	loSize, hiSize int         // live elements in each heap
+	live           map[int]int // value -> live occurrences
+	removed        map[int]int // value -> removed occurrences still in a heap
+}
+
+func NewSlidingMedian() *SlidingMedian {
+	return &SlidingMedian{
+		lo:      heap.NewMaxHeap[int](),
+		hi:      NewIntMinHeap(),
+		live:    make(map[int]int),
+		removed: make(map[int]int),
+	}
+}
+
+func (s *SlidingMedian) Len() int {
+	return s.loSize + s.hiSize
+}
+
+func (s *SlidingMedian) Add(x int) {
+	s.live[x]++
+	if top, ok := s.lo.Peek(); !ok || x <= top {
+		s.lo.Push(x)
+		s.loSize++
+	} else {
+		s.hi.Push(x)
+		s.hiSize++
+	}
+	s.rebalance()
+}
+
+// Remove drops one occurrence of x and reports whether there was one.
+func (s *SlidingMedian) Remove(x int) bool {
+	if s.live[x] == 0 {
+		return false
+	}
+	if s.live[x]--; s.live[x] == 0 {
+		delete(s.live, x)
+	}
+	s.removed[x]++
+
+	// Both tops are always live, so comparing with the lower top tells us
+	// which half x belongs to.
+	top, _ := s.lo.Peek()
+	if x <= top {
+		s.loSize--
+		if x == top {
+			s.prune(s.lo)
+		}
+	} else {
+		s.hiSize--
+		if htop, _ := s.hi.Peek(); x == htop {
+			s.prune(s.hi)
+		}
+	}
+	s.rebalance()
+	return true
+}
+
+// Median returns the median of the live values.
+func (s *SlidingMedian) Median() (float64, bool) {
+	return median(s.lo, s.hi, s.loSize, s.hiSize)
+}
+
+func (s *SlidingMedian) rebalance() {
+	if s.loSize > s.hiSize+1 {
+		v, _ := s.lo.Pop()
+		s.hi.Push(v)
+		s.loSize--
+		s.hiSize++
+		s.prune(s.lo)
+	} else if s.hiSize > s.loSize {
+		v, _ := s.hi.Pop()
+		s.lo.Push(v)
+		s.hiSize--
+		s.loSize++
+		s.prune(s.hi)
+	}
+}
+
+// prune pops removed values off the top of h.
+func (s *SlidingMedian) prune(h *heap.Heap[int]) {
+	for {
+		top, ok := h.Peek()
+		if !ok || s.removed[top] == 0 {
+			return
+		}
+		if s.removed[top]--; s.removed[top] == 0 {
+			delete(s.removed, top)
+		}
+		h.Pop()
+	}
+}
+
+// WindowMedians returns the median of every length-k window of nums.
+func WindowMedians(nums []int, k int) []float64 {
+	if k <= 0 || k > len(nums) {
+		return nil
+	}
+	s := NewSlidingMedian()
+	out := make([]float64, 0, len(nums)-k+1)
+	for i, x := range nums {
+		s.Add(x)
+		if i >= k {
+			s.Remove(nums[i-k])
+		}
+		if i >= k-1 {
+			m, _ := s.Median()
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func median(lo, hi *heap.Heap[int], loSize, hiSize int) (float64, bool) {
+	if loSize == 0 {
+		return 0, false
+	}
+	a, _ := lo.Peek()
+	if loSize > hiSize {
+		return float64(a), true
+	}
+	b, _ := hi.Peek()
+	return (float64(a) + float64(b)) / 2, true
+}
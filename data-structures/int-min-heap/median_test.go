@@ -0,0 +1,103 @@
+package intminheap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// sortedMedian is the reference: sort a copy and take the middle.
+func sortedMedian(vals []int) float64 {
+	s := slices.Sorted(slices.Values(vals))
+	n := len(s)
+	if n%2 == 1 {
+		return float64(s[n/2])
+	}
+	return (float64(s[n/2-1]) + float64(s[n/2])) / 2
+}
+
+func TestMedianTracker(t *testing.T) {
+	m := NewMedianTracker()
+	if _, ok := m.Median(); ok {
+		t.Fatalf("expected Median on empty tracker to return ok=false")
+	}
+
+	r := rand.New(rand.NewPCG(15, 16))
+	var seen []int
+	for i := 0; i < 500; i++ {
+		x := r.IntN(1000) - 500
+		m.Add(x)
+		seen = append(seen, x)
+		if got, want := mustMedian(t, m.Median), sortedMedian(seen); got != want {
+			t.Fatalf("after %d adds Median got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestSlidingMedian_AddRemove(t *testing.T) {
+	s := NewSlidingMedian()
+	if s.Remove(1) {
+		t.Fatalf("expected Remove on empty tracker to return false")
+	}
+
+	r := rand.New(rand.NewPCG(17, 18))
+	var live []int
+	for i := 0; i < 3000; i++ {
+		// Small value range so duplicates are common.
+		if len(live) > 0 && r.IntN(2) == 0 {
+			j := r.IntN(len(live))
+			if !s.Remove(live[j]) {
+				t.Fatalf("Remove(%d) of a live value returned false", live[j])
+			}
+			live = slices.Delete(live, j, j+1)
+		} else {
+			x := r.IntN(20)
+			s.Add(x)
+			live = append(live, x)
+		}
+
+		if s.Len() != len(live) {
+			t.Fatalf("Len got %d, want %d", s.Len(), len(live))
+		}
+		got, ok := s.Median()
+		if len(live) == 0 {
+			if ok {
+				t.Fatalf("expected ok=false once empty")
+			}
+			continue
+		}
+		if want := sortedMedian(live); !ok || got != want {
+			t.Fatalf("op %d: Median got (%v, %v), want %v", i, got, ok, want)
+		}
+	}
+}
+
+func TestWindowMedians(t *testing.T) {
+	tests := []struct {
+		name string
+		nums []int
+		k    int
+		want []float64
+	}{
+		{"classic", []int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []float64{1, -1, -1, 3, 5, 6}},
+		{"even_window", []int{1, 2, 3, 4, 2, 3, 1, 4, 2}, 4, []float64{2.5, 2.5, 3, 2.5, 2.5, 2.5}},
+		{"window_of_one", []int{4, 2}, 1, []float64{4, 2}},
+		{"k_too_big", []int{1}, 2, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := WindowMedians(tc.nums, tc.k); !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func mustMedian(t *testing.T, f func() (float64, bool)) float64 {
+	t.Helper()
+	m, ok := f()
+	if !ok {
+		t.Fatalf("expected a median")
+	}
+	return m
+}
@@ -0,0 +1,150 @@
+package datastructures
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrClosed = errors.New("queue: closed")
+
+// BlockingQueue is a bounded FIFO queue for handing work between goroutines,
+// like a buffered channel whose depth can be inspected and whose blocking
+// operations honor a context.
+//
+// Close follows channel semantics where it can: items queued before Close
+// can still be taken, and Take reports ErrClosed only once the queue is both
+// closed and drained. Unlike a channel, Put on a closed queue returns
+// ErrClosed instead of panicking, and Close is idempotent.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    Queue[T]
+	capacity int
+	closed   bool
+	notEmpty chan struct{} // closed and replaced when an item arrives or on Close
+	notFull  chan struct{} // closed and replaced when an item leaves or on Close
+}
+
+// NewBlockingQueue returns an empty queue holding at most capacity items. It
+// panics if capacity is not positive.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	return &BlockingQueue[T]{
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put adds v, blocking while the queue is full. It returns ctx.Err() if ctx
+// is done first, or ErrClosed if the queue is closed.
+func (q *BlockingQueue[T]) Put(ctx context.Context, v T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.items.Len() < q.capacity {
+			q.enqueue(v)
+			q.mu.Unlock()
+			return nil
+		}
+		wait := q.notFull
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// Take removes and returns the front item, blocking while the queue is
+// empty. It returns ctx.Err() if ctx is done first, or ErrClosed if the queue
+// is closed and drained.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if v, ok := q.dequeue(); ok {
+			q.mu.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		wait := q.notEmpty
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// TryPut adds v if there is room and the queue is open, without blocking.
+func (q *BlockingQueue[T]) TryPut(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.items.Len() >= q.capacity {
+		return false
+	}
+	q.enqueue(v)
+	return true
+}
+
+// TryTake removes and returns the front item if there is one, without
+// blocking.
+func (q *BlockingQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dequeue()
+}
+
+// Close stops the queue accepting items and wakes every blocked caller.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.notEmpty)
+	close(q.notFull)
+}
+
+// Len returns the number of queued items.
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+func (q *BlockingQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// enqueue and dequeue must be called with q.mu held.
+
+func (q *BlockingQueue[T]) enqueue(v T) {
+	q.items.Enqueue(v)
+	close(q.notEmpty)
+	q.notEmpty = make(chan struct{})
+}
+
+func (q *BlockingQueue[T]) dequeue() (T, bool) {
+	v, ok := q.items.Dequeue()
+	if ok && !q.closed {
+		close(q.notFull)
+		q.notFull = make(chan struct{})
+	}
+	return v, ok
+}
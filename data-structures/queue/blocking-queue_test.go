@@ -0,0 +1,185 @@
+package datastructures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue_TryPutTryTake(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	if !q.TryPut(1) || !q.TryPut(2) {
+		t.Fatalf("expected TryPut to succeed while there is room")
+	}
+	if q.TryPut(3) {
+		t.Fatalf("expected TryPut to fail when full")
+	}
+	if q.Len() != 2 || q.Cap() != 2 {
+		t.Fatalf("Len/Cap got (%d, %d), want (2, 2)", q.Len(), q.Cap())
+	}
+
+	if v, ok := q.TryTake(); !ok || v != 1 {
+		t.Fatalf("TryTake got (%v, %v), want (1, true)", v, ok)
+	}
+	if v, ok := q.TryTake(); !ok || v != 2 {
+		t.Fatalf("TryTake got (%v, %v), want (2, true)", v, ok)
+	}
+	if _, ok := q.TryTake(); ok {
+		t.Fatalf("expected TryTake on empty queue to return ok=false")
+	}
+}
+
+func TestBlockingQueue_PutBlocksUntilTake(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	q.TryPut(1)
+
+	ctx := &waitSignal{Context: context.Background(), waiting: make(chan struct{})}
+	done := make(chan error)
+	go func() {
+		done <- q.Put(ctx, 2)
+	}()
+
+	<-ctx.waiting
+	select {
+	case <-done:
+		t.Fatalf("Put returned while the queue was full")
+	default:
+	}
+
+	if v, _ := q.Take(context.Background()); v != 1 {
+		t.Fatalf("Take got %d, want 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Put got err %v", err)
+	}
+	if v, _ := q.Take(context.Background()); v != 2 {
+		t.Fatalf("Take got %d, want 2", v)
+	}
+}
+
+func TestBlockingQueue_ContextTimeouts(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Take got err %v, want context.DeadlineExceeded", err)
+	}
+
+	q.TryPut(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Put got err %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// waitSignal is a context that closes waiting the first time Done is asked
+// for, which the blocking calls only do once they have decided to wait.
+type waitSignal struct {
+	context.Context
+	waiting chan struct{}
+	once    sync.Once
+}
+
+func (c *waitSignal) Done() <-chan struct{} {
+	c.once.Do(func() { close(c.waiting) })
+	return c.Context.Done()
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	q := NewBlockingQueue[string](4)
+	q.TryPut("a")
+	q.TryPut("b")
+
+	// A taker blocked on an empty queue is woken by Close.
+	empty := NewBlockingQueue[string](1)
+	ctx := &waitSignal{Context: context.Background(), waiting: make(chan struct{})}
+	woken := make(chan error)
+	go func() {
+		_, err := empty.Take(ctx)
+		woken <- err
+	}()
+	<-ctx.waiting
+	empty.Close()
+	if err := <-woken; !errors.Is(err, ErrClosed) {
+		t.Fatalf("blocked Take got err %v, want ErrClosed", err)
+	}
+
+	q.Close()
+	q.Close() // idempotent
+
+	if err := q.Put(context.Background(), "c"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Put after Close got err %v, want ErrClosed", err)
+	}
+	if q.TryPut("c") {
+		t.Fatalf("expected TryPut after Close to fail")
+	}
+
+	// Queued items drain before ErrClosed, like a closed channel.
+	for _, want := range []string{"a", "b"} {
+		if v, err := q.Take(context.Background()); err != nil || v != want {
+			t.Fatalf("Take got (%q, %v), want (%q, nil)", v, err, want)
+		}
+	}
+	if _, err := q.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Take on drained queue got err %v, want ErrClosed", err)
+	}
+}
+
+// Producers and consumers hammer a small queue; every item arrives exactly
+// once. Run with -race.
+func TestBlockingQueue_ProducersConsumers(t *testing.T) {
+	const producers, perProducer = 4, 500
+	q := NewBlockingQueue[int](8)
+	ctx := context.Background()
+
+	var pwg sync.WaitGroup
+	for p := range producers {
+		pwg.Add(1)
+		go func() {
+			defer pwg.Done()
+			for i := range perProducer {
+				if err := q.Put(ctx, p*perProducer+i); err != nil {
+					t.Errorf("Put: %v", err)
+					return
+				}
+				if n := q.Len(); n > q.Cap() {
+					t.Errorf("Len %d exceeds capacity %d", n, q.Cap())
+				}
+			}
+		}()
+	}
+	go func() {
+		pwg.Wait()
+		q.Close()
+	}()
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var cwg sync.WaitGroup
+	for range 3 {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, err := q.Take(ctx)
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				mu.Lock()
+				if seen[v] {
+					t.Errorf("item %d taken twice", v)
+				}
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	cwg.Wait()
+
+	if len(seen) != producers*perProducer {
+		t.Fatalf("took %d items, want %d", len(seen), producers*perProducer)
+	}
+}
@@ -0,0 +1,133 @@
+package datastructures
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
+)
+
+// Clock tells the timed queues what time it is and wakes them when a
+// deadline passes. Tests can supply a fake clock to control due times and
+// visibility timeouts without sleeping.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type delayedItem[T any] struct {
+	item T
+	at   time.Time
+	seq  uint64 // keeps items due at the same instant in FIFO order
+}
+
+// DelayQueue holds items until their scheduled time. Items are kept in a
+// heap ordered by due time, so a single consumer blocked in Take can manage
+// any number of pending items (e.g. retries waiting out their backoff)
+// instead of parking one sleeping goroutine per item. It is safe for
+// concurrent use.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	items   *heap.Heap[delayedItem[T]]
+	seq     uint64
+	changed chan struct{} // closed and replaced when the earliest item changes
+	clock   Clock
+}
+
+// NewDelayQueue returns an empty queue. A nil clock uses the system clock.
+func NewDelayQueue[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &DelayQueue[T]{
+		items: heap.New(func(a, b delayedItem[T]) bool {
+			if !a.at.Equal(b.at) {
+				return a.at.Before(b.at)
+			}
+			return a.seq < b.seq
+		}),
+		changed: make(chan struct{}),
+		clock:   clock,
+	}
+}
+
+// Schedule adds item to become available at at.
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	d := delayedItem[T]{item: item, at: at, seq: q.seq}
+	q.items.Push(d)
+	if top, _ := q.items.Peek(); top.seq == d.seq {
+		// Waiters may be sleeping until a later deadline; wake them to
+		// re-check.
+		close(q.changed)
+		q.changed = make(chan struct{})
+	}
+}
+
+// ScheduleAfter adds item to become available after delay.
+func (q *DelayQueue[T]) ScheduleAfter(item T, delay time.Duration) {
+	q.Schedule(item, q.clock.Now().Add(delay))
+}
+
+// Len returns the number of pending items, due or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// TryTake removes and returns the earliest item if it is due, without
+// blocking.
+func (q *DelayQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if top, ok := q.items.Peek(); ok && !top.at.After(q.clock.Now()) {
+		q.items.Pop()
+		return top.item, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Take blocks until the earliest item is due and returns it, or returns
+// ctx.Err() if ctx is done first.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		top, ok := q.items.Peek()
+		var wait time.Duration
+		if ok {
+			if wait = top.at.Sub(q.clock.Now()); wait <= 0 {
+				q.items.Pop()
+				q.mu.Unlock()
+				return top.item, nil
+			}
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		var due <-chan time.Time
+		if ok {
+			due = q.clock.After(wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-changed:
+		case <-due:
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package datastructures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when told to. Timers handed out by After fire as
+// Advance passes their deadline.
+type fakeClock struct {
+	mu     sync.Mutex
+	parked *sync.Cond // signalled when a timer is added
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Unix(0, 0)}
+	c.parked = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), c: ch})
+	c.parked.Broadcast()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// BlockUntil waits until n timers are pending, i.e. until a waiter has
+// parked on the clock.
+func (c *fakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.parked.Wait()
+	}
+}
+
+func TestDelayQueue_OrdersByDueTime(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[string](clock)
+	now := clock.Now()
+	q.Schedule("c", now.Add(30*time.Millisecond))
+	q.Schedule("a", now.Add(-time.Second))
+	q.Schedule("b", now.Add(10*time.Millisecond))
+	q.Schedule("a2", now.Add(-time.Second)) // same instant as "a", queued after it
+
+	if q.Len() != 4 {
+		t.Fatalf("Len got %d, want 4", q.Len())
+	}
+
+	for _, step := range []struct {
+		advance time.Duration
+		want    string
+	}{{0, "a"}, {0, "a2"}, {10 * time.Millisecond, "b"}, {20 * time.Millisecond, "c"}} {
+		if step.advance > 0 {
+			if v, ok := q.TryTake(); ok {
+				t.Fatalf("TryTake got %q before %q was due", v, step.want)
+			}
+			clock.Advance(step.advance)
+		}
+		got, err := q.Take(context.Background())
+		if err != nil || got != step.want {
+			t.Fatalf("Take got (%q, %v), want (%q, nil)", got, err, step.want)
+		}
+	}
+}
+
+func TestDelayQueue_TryTake(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+	if _, ok := q.TryTake(); ok {
+		t.Fatalf("expected TryTake on empty queue to return ok=false")
+	}
+
+	q.ScheduleAfter(1, time.Hour)
+	if _, ok := q.TryTake(); ok {
+		t.Fatalf("expected TryTake to skip an item that isn't due")
+	}
+	q.Schedule(2, clock.Now())
+	if v, ok := q.TryTake(); !ok || v != 2 {
+		t.Fatalf("TryTake got (%v, %v), want (2, true)", v, ok)
+	}
+	clock.Advance(time.Hour)
+	if v, ok := q.TryTake(); !ok || v != 1 {
+		t.Fatalf("TryTake got (%v, %v), want (1, true)", v, ok)
+	}
+}
+
+func TestDelayQueue_EarlierScheduleWakesTaker(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[string](clock)
+	q.ScheduleAfter("late", time.Hour)
+
+	got := make(chan string)
+	take := func() {
+		v, _ := q.Take(context.Background())
+		got <- v
+	}
+	go take()
+
+	clock.BlockUntil(1) // Take is waiting on "late"
+	q.ScheduleAfter("soon", 5*time.Millisecond)
+	clock.BlockUntil(2) // and now on "soon"
+
+	select {
+	case v := <-got:
+		t.Fatalf("Take returned %q before anything was due", v)
+	default:
+	}
+	clock.Advance(5 * time.Millisecond)
+	if v := <-got; v != "soon" {
+		t.Fatalf("Take got %q, want \"soon\"", v)
+	}
+
+	go take()
+	clock.BlockUntil(2) // the stale "late" timer from the first Take, plus this one
+	clock.Advance(time.Hour)
+	if v := <-got; v != "late" {
+		t.Fatalf("Take got %q, want \"late\"", v)
+	}
+}
+
+func TestDelayQueue_TakeHonorsContext(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+	q.ScheduleAfter(1, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		_, err := q.Take(ctx)
+		errc <- err
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take got err %v, want context.Canceled", err)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("expected the pending item to stay queued")
+	}
+}
+
+// Many producers, several consumers; every item is taken exactly once.
+// Run with -race.
+func TestDelayQueue_Concurrent(t *testing.T) {
+	const producers, perProducer = 4, 250
+	q := NewDelayQueue[int](nil) // real time, to exercise the system clock
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var consumers sync.WaitGroup
+	for range 3 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				v, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				if seen[v] {
+					t.Errorf("item %d taken twice", v)
+				}
+				seen[v] = true
+				done := len(seen) == producers*perProducer
+				mu.Unlock()
+				if done {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for p := range producers {
+		go func() {
+			for i := range perProducer {
+				q.ScheduleAfter(p*perProducer+i, time.Duration(i%5)*time.Millisecond)
+			}
+		}()
+	}
+	consumers.Wait()
+
+	if len(seen) != producers*perProducer {
+		t.Fatalf("took %d items, want %d", len(seen), producers*perProducer)
+	}
+}
@@ -0,0 +1,145 @@
+package datastructures
+
+import "iter"
+
+const minDequeCap = 8
+
+// Deque is a double-ended queue backed by a growable circular buffer. Both
+// ends support O(1) amortized push and pop, and At gives O(1) indexed access.
+// Removed slots are zeroed so their values can be garbage collected, and the
+// buffer halves once it is at most a quarter full. The zero value is an
+// empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.n)] = v
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.n++
+}
+
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	v := d.buf[d.head]
+	var zero T
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+func (d *Deque[T]) PopBack() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	i := d.index(d.n - 1)
+	v := d.buf[i]
+	var zero T
+	d.buf[i] = zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// At returns the i-th element from the front. It panics if i is out of
+// range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("queue: Deque index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// Clear empties the deque and releases its buffer.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// All yields the elements from front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields the elements from back to front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index maps a position from the front to a buffer index.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+func (d *Deque[T]) grow() {
+	if d.n == len(d.buf) {
+		d.resize(max(2*len(d.buf), minDequeCap))
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.n <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the elements to the front of a new buffer of size c.
+func (d *Deque[T]) resize(c int) {
+	buf := make([]T, c)
+	if d.n > 0 {
+		if d.head+d.n <= len(d.buf) {
+			copy(buf, d.buf[d.head:d.head+d.n])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:d.n-k])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
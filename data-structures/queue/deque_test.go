@@ -0,0 +1,104 @@
+package datastructures
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestDeque_BothEnds(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.Front(); ok {
+		t.Fatalf("expected Front on empty deque to return ok=false")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Fatalf("expected PopBack on empty deque to return ok=false")
+	}
+
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	d.PushFront(0)
+
+	if got := slices.Collect(d.All()); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("All got %v, want [0 1 2 3]", got)
+	}
+	if got := slices.Collect(d.Backward()); !slices.Equal(got, []int{3, 2, 1, 0}) {
+		t.Fatalf("Backward got %v, want [3 2 1 0]", got)
+	}
+	if v, _ := d.Front(); v != 0 {
+		t.Fatalf("Front got %d, want 0", v)
+	}
+	if v, _ := d.Back(); v != 3 {
+		t.Fatalf("Back got %d, want 3", v)
+	}
+	if d.At(2) != 2 {
+		t.Fatalf("At(2) got %d, want 2", d.At(2))
+	}
+
+	if v, ok := d.PopBack(); !ok || v != 3 {
+		t.Fatalf("PopBack got (%v, %v), want (3, true)", v, ok)
+	}
+	if v, ok := d.PopFront(); !ok || v != 0 {
+		t.Fatalf("PopFront got (%v, %v), want (0, true)", v, ok)
+	}
+	if d.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", d.Len())
+	}
+}
+
+func TestDeque_AtOutOfRangePanics(t *testing.T) {
+	var d Deque[int]
+	d.PushBack(1)
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected At(1) to panic")
+		}
+	}()
+	d.At(1)
+}
+
+func TestDeque_RandomAgainstSlice(t *testing.T) {
+	r := rand.New(rand.NewPCG(19, 20))
+	var d Deque[int]
+	var ref []int
+
+	for i := 0; i < 5000; i++ {
+		switch r.IntN(4) {
+		case 0:
+			d.PushFront(i)
+			ref = slices.Insert(ref, 0, i)
+		case 1:
+			d.PushBack(i)
+			ref = append(ref, i)
+		case 2:
+			v, ok := d.PopFront()
+			if ok != (len(ref) > 0) || (ok && v != ref[0]) {
+				t.Fatalf("op %d: PopFront got (%v, %v), ref %v", i, v, ok, ref)
+			}
+			if ok {
+				ref = ref[1:]
+			}
+		case 3:
+			v, ok := d.PopBack()
+			if ok != (len(ref) > 0) || (ok && v != ref[len(ref)-1]) {
+				t.Fatalf("op %d: PopBack got (%v, %v)", i, v, ok)
+			}
+			if ok {
+				ref = ref[:len(ref)-1]
+			}
+		}
+		if d.Len() != len(ref) {
+			t.Fatalf("op %d: Len got %d, want %d", i, d.Len(), len(ref))
+		}
+		if len(ref) > 0 {
+			j := r.IntN(len(ref))
+			if d.At(j) != ref[j] {
+				t.Fatalf("op %d: At(%d) got %d, want %d", i, j, d.At(j), ref[j])
+			}
+		}
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, ref) {
+		t.Fatalf("All got %v, want %v", got, ref)
+	}
+}
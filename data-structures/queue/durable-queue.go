@@ -0,0 +1,510 @@
+package datastructures
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
+)
+
+var (
+	ErrUnknownID = errors.New("queue: unknown or already acked id")
+	ErrCorrupt   = errors.New("queue: corrupt segment")
+	ErrTooLarge  = errors.New("queue: encoded item too large")
+)
+
+const (
+	opEnqueue byte = 1
+	opAck     byte = 2
+
+	segmentExt       = ".seg"
+	frameHeaderSize  = 8 // payload length + CRC-32, both uint32
+	recordHeaderSize = 9 // op + id
+	maxRecordSize    = 64 << 20
+	maxItemSize      = maxRecordSize - recordHeaderSize
+)
+
+type DurableOptions struct {
+	// SegmentSize is the size in bytes after which the log rolls over to a
+	// new segment file. Defaults to 4 MiB.
+	SegmentSize int64
+	// VisibilityTimeout is how long a dequeued item stays hidden from other
+	// consumers before it is redelivered unless acked. Defaults to 30s.
+	VisibilityTimeout time.Duration
+	// Sync fsyncs the log after every write. Without it a machine crash
+	// (not just a process exit) can lose the most recent writes.
+	Sync bool
+	// Clock supplies the current time for visibility timeouts. Defaults to
+	// the system clock.
+	Clock Clock
+}
+
+// Delivery is an item handed out by Dequeue. Its ID must be passed to Ack
+// once the item has been processed, or to Nack to give it back.
+type Delivery[T any] struct {
+	ID    uint64
+	Value T
+}
+
+type segment struct {
+	base uint64 // lower bound on the IDs first enqueued in the segment; also its file name
+	path string
+	size int64
+	live int // enqueue records in this segment not yet acked
+}
+
+type durableItem struct {
+	data        []byte
+	seg         *segment
+	leasedUntil time.Time // zero while the item is ready for delivery
+}
+
+// segmentFile is the part of *os.File the log writes through; tests swap in
+// a failing implementation.
+type segmentFile interface {
+	io.Writer
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+type lease struct {
+	id    uint64
+	until time.Time
+}
+
+// DurableQueue is a FIFO queue persisted to an append-only log of segment
+// files in a directory, so queued items survive a process exit.
+//
+// Items are JSON-encoded. Dequeue leases an item rather than removing it: the
+// item stays in the log until Ack, and becomes deliverable again after Nack
+// or once its visibility timeout passes, so delivery is at-least-once.
+// Leases live only in memory; on reopen every unacked item is ready again.
+//
+// Each log record is framed with its length and a CRC, so a record torn by a
+// crash at the end of the log is detected and truncated on reopen. Segments
+// are deleted once every item in them, and every segment before them, has
+// been acked; Compact additionally rewrites live items out of old segments.
+// Payloads are kept in memory as well as on disk.
+type DurableQueue[T any] struct {
+	mu       sync.Mutex
+	dir      string
+	opts     DurableOptions
+	segments []*segment
+	active   segmentFile
+	nextID   uint64
+	items    map[uint64]*durableItem
+	ready    *heap.Heap[uint64]
+	leases   *heap.Heap[lease]
+	closed   bool
+}
+
+// OpenDurableQueue opens the queue stored in dir, creating dir if needed, and
+// recovers any items left unacked by a previous run.
+func OpenDurableQueue[T any](dir string, opts DurableOptions) (*DurableQueue[T], error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = 4 << 20
+	}
+	if opts.VisibilityTimeout <= 0 {
+		opts.VisibilityTimeout = 30 * time.Second
+	}
+	if opts.Clock == nil {
+		opts.Clock = systemClock{}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &DurableQueue[T]{
+		dir:    dir,
+		opts:   opts,
+		nextID: 1,
+		items:  make(map[uint64]*durableItem),
+		ready:  heap.NewMinHeap[uint64](),
+		leases: heap.New(func(a, b lease) bool { return a.until.Before(b.until) }),
+	}
+	if err := q.recover(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Enqueue appends v to the queue and returns its ID. Items whose encoding
+// exceeds 64 MiB are rejected with ErrTooLarge.
+func (q *DurableQueue[T]) Enqueue(v T) (uint64, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) > maxItemSize {
+		return 0, fmt.Errorf("%w: %d bytes", ErrTooLarge, len(data))
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return 0, ErrClosed
+	}
+
+	id := q.nextID
+	seg, err := q.append(opEnqueue, id, data)
+	if err != nil {
+		return 0, err
+	}
+	q.nextID++
+	seg.live++
+	q.items[id] = &durableItem{data: data, seg: seg}
+	q.ready.Push(id)
+	return id, nil
+}
+
+// Dequeue leases the oldest ready item. It returns ok=false if no item is
+// ready; items that are leased to other consumers don't count.
+//
+// If the item can't be decoded into a T, Dequeue still leases it and
+// returns its ID along with the error, so it doesn't block the items behind
+// it: it is redelivered after the visibility timeout like any other, and
+// the caller can Ack the ID to discard it.
+func (q *DurableQueue[T]) Dequeue() (d Delivery[T], ok bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return d, false, ErrClosed
+	}
+
+	now := q.opts.Clock.Now()
+	q.reclaim(now)
+	for {
+		id, ok := q.ready.Pop()
+		if !ok {
+			return d, false, nil
+		}
+		it, live := q.items[id]
+		if !live || !it.leasedUntil.IsZero() {
+			continue // acked while it was waiting
+		}
+		it.leasedUntil = now.Add(q.opts.VisibilityTimeout)
+		q.leases.Push(lease{id: id, until: it.leasedUntil})
+		d.ID = id
+		if err := json.Unmarshal(it.data, &d.Value); err != nil {
+			return d, false, fmt.Errorf("queue: decoding item %d: %w", id, err)
+		}
+		return d, true, nil
+	}
+}
+
+// Ack marks the item as processed and removes it from the queue for good.
+func (q *DurableQueue[T]) Ack(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+
+	it, ok := q.items[id]
+	if !ok {
+		return ErrUnknownID
+	}
+	if _, err := q.append(opAck, id, nil); err != nil {
+		return err
+	}
+	delete(q.items, id)
+	it.seg.live--
+	return q.dropAckedSegments()
+}
+
+// Nack returns a leased item to the queue so it can be redelivered
+// immediately. Nack on an item that is not leased is a no-op.
+func (q *DurableQueue[T]) Nack(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+
+	it, ok := q.items[id]
+	if !ok {
+		return ErrUnknownID
+	}
+	if !it.leasedUntil.IsZero() {
+		it.leasedUntil = time.Time{}
+		q.ready.Push(id)
+	}
+	return nil
+}
+
+// Len returns the number of unacked items, whether ready or leased.
+func (q *DurableQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Compact copies the unacked items out of every segment but the active one
+// and deletes those segments, reclaiming the space held by acked items.
+func (q *DurableQueue[T]) Compact() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+
+	sealed := q.segments[:len(q.segments)-1]
+	if len(sealed) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(q.items))
+	for id, it := range q.items {
+		if slices.Contains(sealed, it.seg) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	// Rewrite first, then delete: a crash in between leaves duplicate
+	// enqueue records, which recovery resolves in favour of the later one.
+	for _, id := range ids {
+		it := q.items[id]
+		seg, err := q.append(opEnqueue, id, it.data)
+		if err != nil {
+			return err
+		}
+		it.seg.live--
+		it.seg = seg
+		seg.live++
+	}
+	return q.dropAckedSegments()
+}
+
+// Close closes the log. The queue can be reopened from the same directory.
+func (q *DurableQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	return q.active.Close()
+}
+
+// reclaim makes items whose lease has expired ready again.
+func (q *DurableQueue[T]) reclaim(now time.Time) {
+	for {
+		l, ok := q.leases.Peek()
+		if !ok || l.until.After(now) {
+			return
+		}
+		q.leases.Pop()
+		// Skip leases that were acked, nacked or superseded by a newer lease.
+		if it, ok := q.items[l.id]; ok && it.leasedUntil.Equal(l.until) {
+			it.leasedUntil = time.Time{}
+			q.ready.Push(l.id)
+		}
+	}
+}
+
+// append writes a record to the active segment, rolling over to a new
+// segment first if the active one is full. It returns the segment written.
+func (q *DurableQueue[T]) append(op byte, id uint64, data []byte) (*segment, error) {
+	seg := q.segments[len(q.segments)-1]
+	if seg.size >= q.opts.SegmentSize {
+		var err error
+		if seg, err = q.roll(); err != nil {
+			return nil, err
+		}
+	}
+
+	payload := make([]byte, recordHeaderSize+len(data))
+	payload[0] = op
+	binary.BigEndian.PutUint64(payload[1:], id)
+	copy(payload[recordHeaderSize:], data)
+
+	frame := make([]byte, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:], crc32.ChecksumIEEE(payload))
+	copy(frame[frameHeaderSize:], payload)
+
+	if _, err := q.active.Write(frame); err != nil {
+		return nil, q.rewind(seg, err)
+	}
+	if q.opts.Sync {
+		if err := q.active.Sync(); err != nil {
+			return nil, q.rewind(seg, err)
+		}
+	}
+	seg.size += int64(len(frame))
+	return seg, nil
+}
+
+// rewind cuts the active segment back to its last complete record after a
+// failed write, so the next record doesn't land behind a partial frame
+// (which recovery would read as a torn tail or as corruption). If even that
+// fails the queue closes itself, since it can no longer append safely.
+func (q *DurableQueue[T]) rewind(seg *segment, cause error) error {
+	err := q.active.Truncate(seg.size)
+	if err == nil {
+		return cause
+	}
+	q.closed = true
+	return errors.Join(cause, fmt.Errorf("queue: closed after failing to roll back write: %w", err), q.active.Close())
+}
+
+func (q *DurableQueue[T]) roll() (*segment, error) {
+	// A segment holding only acks doesn't advance nextID, so the next one
+	// may need a higher base to get a fresh name.
+	base := q.nextID
+	if n := len(q.segments); n > 0 {
+		base = max(base, q.segments[n-1].base+1)
+	}
+	seg := &segment{base: base, path: q.segmentPath(base)}
+	f, err := os.OpenFile(seg.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if q.active != nil {
+		if err := q.active.Close(); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	q.active = f
+	q.segments = append(q.segments, seg)
+	return seg, nil
+}
+
+// dropAckedSegments deletes leading segments with no unacked items. Only a
+// prefix can go: a later segment may hold the acks for items in an earlier
+// one, and losing those would resurrect the items on recovery.
+func (q *DurableQueue[T]) dropAckedSegments() error {
+	for len(q.segments) > 1 && q.segments[0].live == 0 {
+		if err := os.Remove(q.segments[0].path); err != nil {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+	return nil
+}
+
+func (q *DurableQueue[T]) segmentPath(base uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", base, segmentExt))
+}
+
+// recover replays every segment in order, then opens the last one for
+// appending (or creates the first one).
+func (q *DurableQueue[T]) recover() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	var bases []uint64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), segmentExt)
+		if !ok || e.IsDir() {
+			continue
+		}
+		base, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		bases = append(bases, base)
+	}
+	slices.Sort(bases)
+
+	for i, base := range bases {
+		seg := &segment{base: base, path: q.segmentPath(base)}
+		q.segments = append(q.segments, seg)
+		q.nextID = max(q.nextID, base)
+		if err := q.replay(seg, i == len(bases)-1); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]uint64, 0, len(q.items))
+	for id := range q.items {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	q.ready = heap.From(ids, func(a, b uint64) bool { return a < b })
+
+	if len(q.segments) == 0 {
+		_, err := q.roll()
+		return err
+	}
+	last := q.segments[len(q.segments)-1]
+	f, err := os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	q.active = f
+	return nil
+}
+
+// replay applies the records in seg. A bad record at the end of the last
+// segment is a write torn by a crash and is truncated away; anywhere else it
+// means the log is corrupt.
+func (q *DurableQueue[T]) replay(seg *segment, last bool) error {
+	f, err := os.Open(seg.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var header [frameHeaderSize]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return q.torn(seg, last, err)
+		}
+		n := binary.BigEndian.Uint32(header[:])
+		if n < recordHeaderSize || n > maxRecordSize {
+			return q.torn(seg, last, fmt.Errorf("bad record length %d", n))
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return q.torn(seg, last, err)
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			return q.torn(seg, last, errors.New("checksum mismatch"))
+		}
+
+		id := binary.BigEndian.Uint64(payload[1:])
+		switch payload[0] {
+		case opEnqueue:
+			if old, ok := q.items[id]; ok {
+				old.seg.live-- // rewritten by Compact
+			}
+			q.items[id] = &durableItem{data: payload[recordHeaderSize:], seg: seg}
+			seg.live++
+			q.nextID = max(q.nextID, id+1)
+		case opAck:
+			if it, ok := q.items[id]; ok {
+				it.seg.live--
+				delete(q.items, id)
+			}
+		default:
+			return q.torn(seg, last, fmt.Errorf("unknown op %d", payload[0]))
+		}
+		seg.size += int64(frameHeaderSize + n)
+	}
+}
+
+func (q *DurableQueue[T]) torn(seg *segment, last bool, cause error) error {
+	if !last {
+		return fmt.Errorf("%w: %s at offset %d: %v", ErrCorrupt, filepath.Base(seg.path), seg.size, cause)
+	}
+	return os.Truncate(seg.path, seg.size)
+}
@@ -0,0 +1,368 @@
+package datastructures
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openDurable(t *testing.T, dir string, opts DurableOptions) *DurableQueue[string] {
+	t.Helper()
+	q, err := OpenDurableQueue[string](dir, opts)
+	if err != nil {
+		t.Fatalf("OpenDurableQueue: %v", err)
+	}
+	t.Cleanup(func() { q.Close() })
+	return q
+}
+
+func mustEnqueue(t *testing.T, q *DurableQueue[string], vals ...string) {
+	t.Helper()
+	for _, v := range vals {
+		if _, err := q.Enqueue(v); err != nil {
+			t.Fatalf("Enqueue(%q): %v", v, err)
+		}
+	}
+}
+
+func mustDequeue(t *testing.T, q *DurableQueue[string], want string) Delivery[string] {
+	t.Helper()
+	d, ok, err := q.Dequeue()
+	if err != nil || !ok || d.Value != want {
+		t.Fatalf("Dequeue got (%q, %v, %v), want (%q, true, nil)", d.Value, ok, err, want)
+	}
+	return d
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestDurableQueue_FIFOAndAck(t *testing.T) {
+	q := openDurable(t, t.TempDir(), DurableOptions{})
+	mustEnqueue(t, q, "a", "b", "c")
+
+	for _, want := range []string{"a", "b", "c"} {
+		d := mustDequeue(t, q, want)
+		if err := q.Ack(d.ID); err != nil {
+			t.Fatalf("Ack(%d): %v", d.ID, err)
+		}
+	}
+	if _, ok, _ := q.Dequeue(); ok {
+		t.Fatalf("expected Dequeue on drained queue to return ok=false")
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len got %d, want 0", q.Len())
+	}
+	if err := q.Ack(1); !errors.Is(err, ErrUnknownID) {
+		t.Fatalf("second Ack got %v, want ErrUnknownID", err)
+	}
+}
+
+func TestDurableQueue_VisibilityTimeout(t *testing.T) {
+	clock := newFakeClock()
+	q := openDurable(t, t.TempDir(), DurableOptions{VisibilityTimeout: time.Minute, Clock: clock})
+	mustEnqueue(t, q, "a", "b")
+
+	mustDequeue(t, q, "a")
+	clock.Advance(30 * time.Second)
+	mustDequeue(t, q, "b")
+	if _, ok, _ := q.Dequeue(); ok {
+		t.Fatalf("expected leased items to be hidden")
+	}
+
+	// a's lease runs out first and it is redelivered ahead of anything newer.
+	clock.Advance(31 * time.Second)
+	mustEnqueue(t, q, "c")
+	a := mustDequeue(t, q, "a")
+	mustDequeue(t, q, "c")
+	if q.Len() != 3 {
+		t.Fatalf("Len got %d, want 3", q.Len())
+	}
+
+	// The stale lease from the first delivery must not reclaim the new one.
+	clock.Advance(59 * time.Second)
+	mustDequeue(t, q, "b")
+	if _, ok, _ := q.Dequeue(); ok {
+		t.Fatalf("expected a to still be leased")
+	}
+	if err := q.Ack(a.ID); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+}
+
+func TestDurableQueue_Nack(t *testing.T) {
+	q := openDurable(t, t.TempDir(), DurableOptions{})
+	mustEnqueue(t, q, "a", "b")
+
+	d := mustDequeue(t, q, "a")
+	if err := q.Nack(d.ID); err != nil {
+		t.Fatalf("Nack: %v", err)
+	}
+	if err := q.Nack(d.ID); err != nil {
+		t.Fatalf("Nack on ready item should be a no-op, got %v", err)
+	}
+	mustDequeue(t, q, "a")
+	mustDequeue(t, q, "b")
+	if err := q.Nack(99); !errors.Is(err, ErrUnknownID) {
+		t.Fatalf("Nack(99) got %v, want ErrUnknownID", err)
+	}
+}
+
+func TestDurableQueue_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{SegmentSize: 64})
+	mustEnqueue(t, q, "a", "b", "c", "d")
+	d := mustDequeue(t, q, "a")
+	q.Ack(d.ID)
+	mustDequeue(t, q, "b") // leased but never acked
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := q.Enqueue("x"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Enqueue after Close got %v, want ErrClosed", err)
+	}
+
+	q = openDurable(t, dir, DurableOptions{SegmentSize: 64})
+	if q.Len() != 3 {
+		t.Fatalf("Len after reopen got %d, want 3", q.Len())
+	}
+	mustEnqueue(t, q, "e")
+	for _, want := range []string{"b", "c", "d", "e"} {
+		d := mustDequeue(t, q, want)
+		if d.ID <= 1 {
+			t.Fatalf("ID %d reused after reopen", d.ID)
+		}
+	}
+}
+
+func TestDurableQueue_TornWrite(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{})
+	mustEnqueue(t, q, "a", "b")
+	q.Close()
+
+	// Simulate a crash halfway through writing a third record.
+	files := segmentFiles(t, dir)
+	f, err := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0, 0, 0, 20, 1, 2})
+	f.Close()
+
+	q = openDurable(t, dir, DurableOptions{})
+	if q.Len() != 2 {
+		t.Fatalf("Len after torn write got %d, want 2", q.Len())
+	}
+	mustEnqueue(t, q, "c")
+	q.Close()
+
+	q = openDurable(t, dir, DurableOptions{})
+	for _, want := range []string{"a", "b", "c"} {
+		mustDequeue(t, q, want)
+	}
+}
+
+func TestDurableQueue_CorruptSealedSegment(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{SegmentSize: 1})
+	mustEnqueue(t, q, "a", "b")
+	q.Close()
+
+	files := segmentFiles(t, dir)
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 0xff
+	os.WriteFile(files[0], data, 0o644)
+
+	if _, err := OpenDurableQueue[string](dir, DurableOptions{}); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("open got %v, want ErrCorrupt", err)
+	}
+}
+
+func TestDurableQueue_DropsAckedSegments(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{SegmentSize: 1}) // one record per segment
+	mustEnqueue(t, q, "a", "b", "c")
+	if n := len(segmentFiles(t, dir)); n != 3 {
+		t.Fatalf("got %d segments, want 3", n)
+	}
+
+	a := mustDequeue(t, q, "a")
+	b := mustDequeue(t, q, "b")
+	q.Ack(b.ID)
+	// b's segment is empty but sits behind a's, so both must stay.
+	if n := len(segmentFiles(t, dir)); n != 4 {
+		t.Fatalf("got %d segments after acking b, want 4", n)
+	}
+	q.Ack(a.ID)
+	// a's ack went to a fifth segment; a and b's segments can now go.
+	if n := len(segmentFiles(t, dir)); n != 3 {
+		t.Fatalf("got %d segments after acking a, want 3", n)
+	}
+
+	q.Close()
+	q = openDurable(t, dir, DurableOptions{SegmentSize: 1})
+	mustDequeue(t, q, "c")
+	if _, ok, _ := q.Dequeue(); ok {
+		t.Fatalf("expected only c to survive reopen")
+	}
+}
+
+func TestDurableQueue_Compact(t *testing.T) {
+	dir := t.TempDir()
+	opts := DurableOptions{SegmentSize: 100} // five small records per segment
+	q := openDurable(t, dir, opts)
+	mustEnqueue(t, q, "a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l")
+	mustDequeue(t, q, "a") // leased, pinning the first segment
+	for _, want := range []string{"b", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+		q.Ack(mustDequeue(t, q, want).ID)
+	}
+	before := segmentFiles(t, dir)
+	if len(before) < 3 {
+		t.Fatalf("got %d segments before Compact, want at least 3", len(before))
+	}
+	old := make(map[string][]byte)
+	for _, f := range before {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		old[f] = data
+	}
+
+	if err := q.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	for f := range old {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be deleted by Compact", filepath.Base(f))
+		}
+	}
+	q.Close()
+
+	// Put the old segments back, as if Compact crashed before deleting
+	// them: recovery must not deliver the rewritten items twice.
+	for f, data := range old {
+		os.WriteFile(f, data, 0o644)
+	}
+	q = openDurable(t, dir, opts)
+	if q.Len() != 2 {
+		t.Fatalf("Len after reopen got %d, want 2", q.Len())
+	}
+	q.Ack(mustDequeue(t, q, "a").ID)
+	q.Ack(mustDequeue(t, q, "l").ID)
+	if _, ok, _ := q.Dequeue(); ok {
+		t.Fatalf("expected queue to be empty")
+	}
+	if n := len(segmentFiles(t, dir)); n != 1 {
+		t.Fatalf("got %d segments once everything is acked, want 1", n)
+	}
+}
+
+func TestDurableQueue_RejectsOversizedItem(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{})
+	mustEnqueue(t, q, "a")
+
+	big := strings.Repeat("x", maxItemSize) // plus quotes once encoded
+	if _, err := q.Enqueue(big); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("Enqueue of oversized item got %v, want ErrTooLarge", err)
+	}
+	mustEnqueue(t, q, "b")
+	q.Close()
+
+	q = openDurable(t, dir, DurableOptions{})
+	if q.Len() != 2 {
+		t.Fatalf("Len after reopen got %d, want 2", q.Len())
+	}
+	mustDequeue(t, q, "a")
+	mustDequeue(t, q, "b")
+}
+
+// shortWriter writes the first n bytes of the next Write and then fails,
+// like a full disk.
+type shortWriter struct {
+	segmentFile
+	n int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	n, _ := w.segmentFile.Write(p[:min(w.n, len(p))])
+	return n, errors.New("disk full")
+}
+
+func TestDurableQueue_FailedWriteIsRolledBack(t *testing.T) {
+	dir := t.TempDir()
+	q := openDurable(t, dir, DurableOptions{})
+	mustEnqueue(t, q, "a")
+
+	good := q.active
+	q.active = &shortWriter{segmentFile: good, n: 5}
+	if _, err := q.Enqueue("lost"); err == nil {
+		t.Fatalf("expected Enqueue to fail")
+	}
+	q.active = good
+	mustEnqueue(t, q, "b")
+	if q.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", q.Len())
+	}
+	q.Close()
+
+	// Without the rollback, "b" would sit behind a partial frame and be
+	// truncated away on reopen.
+	q = openDurable(t, dir, DurableOptions{})
+	if q.Len() != 2 {
+		t.Fatalf("Len after reopen got %d, want 2", q.Len())
+	}
+	mustDequeue(t, q, "a")
+	mustDequeue(t, q, "b")
+}
+
+func TestDurableQueue_UndecodableItemIsRedelivered(t *testing.T) {
+	dir := t.TempDir()
+	strs := openDurable(t, dir, DurableOptions{})
+	mustEnqueue(t, strs, "not a number")
+	strs.Close()
+
+	clock := newFakeClock()
+	q, err := OpenDurableQueue[int](dir, DurableOptions{VisibilityTimeout: time.Minute, Clock: clock})
+	if err != nil {
+		t.Fatalf("OpenDurableQueue: %v", err)
+	}
+	defer q.Close()
+	if _, err := q.Enqueue(2); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	bad, ok, err := q.Dequeue()
+	if err == nil || ok || bad.ID != 1 {
+		t.Fatalf("Dequeue got (id %d, %v, %v), want (id 1, false, decode error)", bad.ID, ok, err)
+	}
+	// The bad item doesn't block the one behind it.
+	if d, ok, err := q.Dequeue(); err != nil || !ok || d.Value != 2 {
+		t.Fatalf("Dequeue got (%v, %v, %v), want (2, true, nil)", d.Value, ok, err)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if again, _, err := q.Dequeue(); err == nil || again.ID != 1 {
+		t.Fatalf("expected the bad item to be redelivered, got (id %d, %v)", again.ID, err)
+	}
+	if err := q.Ack(bad.ID); err != nil {
+		t.Fatalf("Ack of bad item: %v", err)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("Len got %d, want 1", q.Len())
+	}
+}
@@ -1,23 +1,38 @@
 package datastructures
 
+import "iter"
+
+// Queue is a FIFO queue: a Deque used from one end to the other.
 type Queue[T any] struct {
-	data []T
+	d Deque[T]
 }
 
 func (q *Queue[T]) Enqueue(v T) {
-	q.data = append(q.data, v)
+	q.d.PushBack(v)
 }
 
 func (q *Queue[T]) Dequeue() (T, bool) {
-	if len(q.data) == 0 {
-		var zero T
-		return zero, false
-	}
-	val := q.data[0]
-	q.data = q.data[1:]
-	return val, true
+	return q.d.PopFront()
+}
+
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
 }
 
 func (q *Queue[T]) IsEmpty() bool {
-	return len(q.data) == 0
-}
\ No newline at end of file
+	return q.d.Len() == 0
+}
+
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// Clear empties the queue and releases its buffer.
+func (q *Queue[T]) Clear() {
+	q.d.Clear()
+}
+
+// All yields the queued values from front to back without dequeuing them.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.d.All()
+}
@@ -61,4 +61,90 @@ func TestQueue_EnqueueMoreBeforeDrain(t *testing.T) {
 	if !q.IsEmpty() {
 		t.Fatalf("expected queue to be empty")
 	}
-}
\ No newline at end of file
+}
+
+func TestQueue_PeekLenClear(t *testing.T) {
+	var q Queue[int]
+	if _, ok := q.Peek(); ok {
+		t.Fatalf("expected Peek on empty queue to return ok=false")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	if front, ok := q.Peek(); !ok || front != 1 {
+		t.Fatalf("Peek got (%v, %v), want (1, true)", front, ok)
+	}
+	if q.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", q.Len())
+	}
+
+	q.Clear()
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Fatalf("expected queue to be empty after Clear")
+	}
+	q.Enqueue(3)
+	if front, ok := q.Dequeue(); !ok || front != 3 {
+		t.Fatalf("Dequeue after Clear got (%v, %v), want (3, true)", front, ok)
+	}
+}
+
+func TestQueue_WrapAroundAndIterate(t *testing.T) {
+	var q Queue[int]
+	next, want := 0, 0
+
+	// Interleave enqueues and dequeues so head wraps the buffer several
+	// times while it grows.
+	for round := 0; round < 50; round++ {
+		for i := 0; i < round%7+1; i++ {
+			q.Enqueue(next)
+			next++
+		}
+		for i := 0; i < round%5; i++ {
+			if v, ok := q.Dequeue(); ok {
+				if v != want {
+					t.Fatalf("Dequeue got %d, want %d", v, want)
+				}
+				want++
+			}
+		}
+
+		var got []int
+		for v := range q.All() {
+			got = append(got, v)
+		}
+		if len(got) != q.Len() {
+			t.Fatalf("All yielded %d values, Len is %d", len(got), q.Len())
+		}
+		for i, v := range got {
+			if v != want+i {
+				t.Fatalf("All got %v, want values from %d", got, want)
+			}
+		}
+	}
+}
+
+func TestQueue_ShrinksAndReleasesValues(t *testing.T) {
+	var q Queue[*int]
+	for i := 0; i < 1000; i++ {
+		q.Enqueue(new(int))
+	}
+	grown := len(q.d.buf)
+
+	for i := 0; i < 990; i++ {
+		q.Dequeue()
+	}
+	if len(q.d.buf) >= grown/4 {
+		t.Fatalf("expected buffer to shrink from %d, still %d", grown, len(q.d.buf))
+	}
+
+	// Only the 10 live values may still be referenced by the buffer.
+	live := 0
+	for _, p := range q.d.buf {
+		if p != nil {
+			live++
+		}
+	}
+	if live != 10 {
+		t.Fatalf("buffer references %d values, want 10", live)
+	}
+}
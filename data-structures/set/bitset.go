@@ -0,0 +1,189 @@
+package datastructures
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math/bits"
+)
+
+const wordBits = 64
+
+var errBitSetData = errors.New("bitset: malformed binary data")
+
+// BitSet is a set of non-negative ints stored one bit per possible value,
+// which for dense values (say IDs 1..10000) takes a tiny fraction of the
+// memory of a Set[int]. It grows as needed to hold the largest value set.
+// The zero value is an empty set ready to use. Methods panic on negative
+// values.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet returns a set with room for values below n before it needs to
+// grow. It panics if n is negative.
+func NewBitSet(n int) *BitSet {
+	if n < 0 {
+		panic("bitset: negative size")
+	}
+	return &BitSet{words: make([]uint64, (n+wordBits-1)/wordBits)}
+}
+
+func checkBit(i int) {
+	if i < 0 {
+		panic("bitset: negative value")
+	}
+}
+
+func (b *BitSet) Set(i int) {
+	checkBit(i)
+	w := i / wordBits
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+	}
+	b.words[w] |= 1 << (i % wordBits)
+}
+
+func (b *BitSet) Clear(i int) {
+	checkBit(i)
+	if w := i / wordBits; w < len(b.words) {
+		b.words[w] &^= 1 << (i % wordBits)
+	}
+}
+
+func (b *BitSet) Test(i int) bool {
+	checkBit(i)
+	w := i / wordBits
+	return w < len(b.words) && b.words[w]&(1<<(i%wordBits)) != 0
+}
+
+// Count returns the number of values in the set.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// NextSet returns the smallest value in the set that is >= i, or ok=false
+// if there is none. To visit every value:
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+//		...
+//	}
+func (b *BitSet) NextSet(i int) (int, bool) {
+	checkBit(i)
+	w := i / wordBits
+	if w >= len(b.words) {
+		return 0, false
+	}
+	// Mask off the bits below i in its own word, then scan whole words.
+	word := b.words[w] >> (i % wordBits) << (i % wordBits)
+	for {
+		if word != 0 {
+			return w*wordBits + bits.TrailingZeros64(word), true
+		}
+		w++
+		if w >= len(b.words) {
+			return 0, false
+		}
+		word = b.words[w]
+	}
+}
+
+// All yields the values in ascending order.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Union adds every value in other to b.
+func (b *BitSet) Union(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// Intersect removes from b every value not in other.
+func (b *BitSet) Intersect(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// Difference removes from b every value in other.
+func (b *BitSet) Difference(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// Equal reports whether b and other hold the same values, regardless of how
+// much room each has allocated.
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range long {
+		if i < len(short) {
+			if short[i] != w {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// MarshalBinary encodes the set as a uvarint word count followed by the
+// words in little-endian order. Trailing empty words are dropped, so equal
+// sets encode identically.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	out := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+8*n), uint64(n))
+	for _, w := range b.words[:n] {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary replaces the contents of b with a set encoded by
+// MarshalBinary.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	n, k := binary.Uvarint(data)
+	if k <= 0 {
+		return errBitSetData
+	}
+	data = data[k:]
+	if n > uint64(len(data))/8 || uint64(len(data)) != 8*n {
+		return errBitSetData
+	}
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	b.words = words
+	return nil
+}
@@ -0,0 +1,200 @@
+package datastructures
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func bitsetOf(vals ...int) *BitSet {
+	var b BitSet
+	for _, v := range vals {
+		b.Set(v)
+	}
+	return &b
+}
+
+func TestBitSet_SetClearTest(t *testing.T) {
+	var b BitSet
+	for _, v := range []int{0, 63, 64, 1000} {
+		b.Set(v)
+	}
+	b.Set(64)
+	for _, v := range []int{0, 63, 64, 1000} {
+		if !b.Test(v) {
+			t.Fatalf("expected Test(%d) to be true", v)
+		}
+	}
+	if b.Test(1) || b.Test(65) || b.Test(1<<20) {
+		t.Fatalf("expected unset values to test false")
+	}
+	if b.Count() != 4 {
+		t.Fatalf("Count got %d, want 4", b.Count())
+	}
+
+	b.Clear(63)
+	b.Clear(1 << 20) // beyond the end: no-op
+	if b.Test(63) || b.Count() != 3 {
+		t.Fatalf("expected Clear(63) to remove it, Count got %d", b.Count())
+	}
+}
+
+func TestBitSet_NegativePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Set(-1) to panic")
+		}
+	}()
+	var b BitSet
+	b.Set(-1)
+}
+
+func TestNewBitSet_NegativeSizePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "bitset: negative size" {
+			t.Fatalf("NewBitSet(-1) panicked with %v, want \"bitset: negative size\"", r)
+		}
+	}()
+	NewBitSet(-1)
+}
+
+func TestBitSet_NextSet(t *testing.T) {
+	b := bitsetOf(3, 64, 130, 191)
+	tests := []struct {
+		from int
+		want int
+		ok   bool
+	}{
+		{0, 3, true},
+		{3, 3, true},
+		{4, 64, true},
+		{65, 130, true},
+		{131, 191, true},
+		{192, 0, false},
+		{5000, 0, false},
+	}
+	for _, tc := range tests {
+		if got, ok := b.NextSet(tc.from); got != tc.want || ok != tc.ok {
+			t.Fatalf("NextSet(%d) got (%d, %v), want (%d, %v)", tc.from, got, ok, tc.want, tc.ok)
+		}
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, []int{3, 64, 130, 191}) {
+		t.Fatalf("All got %v", got)
+	}
+}
+
+func TestBitSet_InPlaceOps(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(a, b *BitSet)
+		want []int
+	}{
+		{"union", (*BitSet).Union, []int{1, 2, 3, 100, 200}},
+		{"intersect", (*BitSet).Intersect, []int{2, 100}},
+		{"difference", (*BitSet).Difference, []int{1, 3}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := bitsetOf(1, 2, 3, 100)
+			tc.op(a, bitsetOf(2, 100, 200))
+			if got := slices.Collect(a.All()); !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// A longer receiver against a shorter argument.
+	a := bitsetOf(1, 500)
+	a.Intersect(bitsetOf(1))
+	if got := slices.Collect(a.All()); !slices.Equal(got, []int{1}) {
+		t.Fatalf("Intersect with shorter set got %v, want [1]", got)
+	}
+}
+
+func TestBitSet_EqualIgnoresCapacity(t *testing.T) {
+	a := NewBitSet(10000)
+	a.Set(5)
+	b := bitsetOf(5, 9000)
+	b.Clear(9000)
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Fatalf("expected sets with the same values to be equal")
+	}
+	b.Set(6)
+	if a.Equal(b) {
+		t.Fatalf("expected sets with different values to differ")
+	}
+}
+
+func TestBitSet_MatchesSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	var b BitSet
+	ref := NewSet[int]()
+	for i := 0; i < 5000; i++ {
+		v := r.IntN(3000)
+		if r.IntN(3) == 0 {
+			b.Clear(v)
+			ref.Remove(v)
+		} else {
+			b.Set(v)
+			ref.Add(v)
+		}
+	}
+	want := ref.Values()
+	slices.Sort(want)
+	if got := slices.Collect(b.All()); !slices.Equal(got, want) {
+		t.Fatalf("BitSet and Set disagree")
+	}
+	if b.Count() != ref.Size() {
+		t.Fatalf("Count got %d, want %d", b.Count(), ref.Size())
+	}
+}
+
+func TestBitSet_Binary(t *testing.T) {
+	a := bitsetOf(0, 7, 64, 9999)
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	var b BitSet
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !a.Equal(&b) {
+		t.Fatalf("round trip got %v, want %v", slices.Collect(b.All()), slices.Collect(a.All()))
+	}
+
+	// Spare capacity doesn't change the encoding.
+	c := NewBitSet(1 << 16)
+	c.Union(a)
+	if cdata, _ := c.MarshalBinary(); string(cdata) != string(data) {
+		t.Fatalf("expected equal sets to encode identically")
+	}
+
+	for _, bad := range [][]byte{nil, {2, 0, 0}, append(data, 0), {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x20}} {
+		if err := b.UnmarshalBinary(bad); err == nil {
+			t.Fatalf("expected UnmarshalBinary(%v) to fail", bad)
+		}
+	}
+}
+
+var sink bool
+
+func BenchmarkMembership(b *testing.B) {
+	const n = 10000
+	bs := NewBitSet(n)
+	s := NewSet[int]()
+	for i := 0; i < n; i += 2 {
+		bs.Set(i)
+		s.Add(i)
+	}
+	b.Run("BitSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink = bs.Test(i % n)
+		}
+	})
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink = s.Has(i % n)
+		}
+	})
+}
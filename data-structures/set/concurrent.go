@@ -0,0 +1,204 @@
+package datastructures
+
+import (
+	"hash/maphash"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	// Pad shards out to separate cache lines, so goroutines hammering
+	// neighbouring shards don't contend on the same line.
+	_ [32]byte
+}
+
+// ShardedMap is a map that is safe for concurrent use. Keys are spread over
+// a fixed number of shards by hash, each with its own lock, so goroutines
+// working on different keys rarely wait for each other.
+//
+// Compared with sync.Map, which is tuned for keys written once and read
+// many times, a ShardedMap holds up better under write-heavy workloads and
+// is typed.
+type ShardedMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []shard[K, V]
+	mask   uint64
+}
+
+// NewShardedMap returns a map with the given number of shards, rounded up
+// to a power of two. shards <= 0 picks a default based on GOMAXPROCS.
+func NewShardedMap[K comparable, V any](shards int) *ShardedMap[K, V] {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	m := &ShardedMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]shard[K, V], n),
+		mask:   uint64(n - 1),
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+func (m *ShardedMap[K, V]) shardFor(k K) *shard[K, V] {
+	return &m.shards[maphash.Comparable(m.seed, k)&m.mask]
+}
+
+func (m *ShardedMap[K, V]) Load(k K) (V, bool) {
+	s := m.shardFor(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return v, ok
+}
+
+func (m *ShardedMap[K, V]) Store(k K, v V) {
+	s := m.shardFor(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+}
+
+// LoadOrStore returns the existing value for k if present, with
+// loaded=true. Otherwise it stores v and returns it with loaded=false.
+func (m *ShardedMap[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	s := m.shardFor(k)
+	// Try a shared lock first: when deduplicating, most keys are repeats.
+	s.mu.RLock()
+	old, ok := s.m[k]
+	s.mu.RUnlock()
+	if ok {
+		return old, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.m[k]; ok {
+		return old, true
+	}
+	s.m[k] = v
+	return v, false
+}
+
+// LoadAndDelete removes k, returning its value if it was present.
+func (m *ShardedMap[K, V]) LoadAndDelete(k K) (V, bool) {
+	s := m.shardFor(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[k]
+	delete(s.m, k)
+	return v, ok
+}
+
+func (m *ShardedMap[K, V]) Delete(k K) {
+	m.LoadAndDelete(k)
+}
+
+// Len returns the number of entries. Under concurrent writes the shards
+// are counted one at a time, so the result is approximate.
+func (m *ShardedMap[K, V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// All yields every entry in no particular order. Each shard is copied under
+// its lock and yielded after unlocking, so the loop body may use the map,
+// but entries changed during iteration may or may not be seen.
+func (m *ShardedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		type entry struct {
+			k K
+			v V
+		}
+		var buf []entry
+		for i := range m.shards {
+			s := &m.shards[i]
+			buf = buf[:0]
+			s.mu.RLock()
+			for k, v := range s.m {
+				buf = append(buf, entry{k, v})
+			}
+			s.mu.RUnlock()
+			for _, e := range buf {
+				if !yield(e.k, e.v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ConcurrentSet is a set that is safe for concurrent use, built on a
+// ShardedMap.
+type ConcurrentSet[T comparable] struct {
+	m *ShardedMap[T, struct{}]
+}
+
+// NewConcurrentSet returns a set with the given number of shards; see
+// NewShardedMap.
+func NewConcurrentSet[T comparable](shards int) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{m: NewShardedMap[T, struct{}](shards)}
+}
+
+func (s *ConcurrentSet[T]) Add(v T) {
+	s.m.Store(v, struct{}{})
+}
+
+// AddIfAbsent adds v and reports whether it was new. When several
+// goroutines add the same value, exactly one of them gets true, which makes
+// it a check-and-claim for deduplicating work.
+func (s *ConcurrentSet[T]) AddIfAbsent(v T) bool {
+	_, loaded := s.m.LoadOrStore(v, struct{}{})
+	return !loaded
+}
+
+// Remove deletes v and reports whether it was present.
+func (s *ConcurrentSet[T]) Remove(v T) bool {
+	_, ok := s.m.LoadAndDelete(v)
+	return ok
+}
+
+func (s *ConcurrentSet[T]) Has(v T) bool {
+	_, ok := s.m.Load(v)
+	return ok
+}
+
+// Size returns the number of values; see ShardedMap.Len.
+func (s *ConcurrentSet[T]) Size() int {
+	return s.m.Len()
+}
+
+// All yields the values in no particular order; see ShardedMap.All.
+func (s *ConcurrentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot copies the current values into a plain Set.
+func (s *ConcurrentSet[T]) Snapshot() *Set[T] {
+	out := NewSet[T]()
+	for v := range s.All() {
+		out.Add(v)
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+package datastructures
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardedMap_Basics(t *testing.T) {
+	m := NewShardedMap[string, int](3)
+	if len(m.shards) != 4 {
+		t.Fatalf("got %d shards, want 3 rounded up to 4", len(m.shards))
+	}
+
+	m.Store("a", 1)
+	m.Store("b", 2)
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Fatalf("Load(a) got (%v, %v), want (1, true)", v, ok)
+	}
+	if v, loaded := m.LoadOrStore("a", 10); !loaded || v != 1 {
+		t.Fatalf("LoadOrStore(a) got (%v, %v), want (1, true)", v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("c", 3); loaded || v != 3 {
+		t.Fatalf("LoadOrStore(c) got (%v, %v), want (3, false)", v, loaded)
+	}
+	if v, ok := m.LoadAndDelete("b"); !ok || v != 2 {
+		t.Fatalf("LoadAndDelete(b) got (%v, %v), want (2, true)", v, ok)
+	}
+	m.Delete("missing")
+	if m.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", m.Len())
+	}
+
+	got := make(map[string]int)
+	for k, v := range m.All() {
+		got[k] = v
+		m.Store(k+"!", v) // writing mid-iteration must not deadlock
+	}
+	if len(got) < 2 || got["a"] != 1 || got["c"] != 3 {
+		t.Fatalf("All got %v, want at least a=1 and c=3", got)
+	}
+}
+
+func TestConcurrentSet_Basics(t *testing.T) {
+	s := NewConcurrentSet[int](0)
+	if !s.AddIfAbsent(1) || s.AddIfAbsent(1) {
+		t.Fatalf("expected AddIfAbsent to report true then false")
+	}
+	s.Add(2)
+	if !s.Has(2) || s.Has(3) || s.Size() != 2 {
+		t.Fatalf("got Has(2)=%v Has(3)=%v Size=%d, want true false 2", s.Has(2), s.Has(3), s.Size())
+	}
+	if !s.Remove(1) || s.Remove(1) {
+		t.Fatalf("expected Remove to report true then false")
+	}
+	if !s.Snapshot().Equal(Of(2)) {
+		t.Fatalf("Snapshot got %v, want [2]", s.Snapshot().Values())
+	}
+}
+
+// Every value is claimed by exactly one of the racing goroutines.
+func TestConcurrentSet_AddIfAbsentRace(t *testing.T) {
+	const goroutines, values = 8, 2000
+	s := NewConcurrentSet[int](0)
+	var wins atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < values; v++ {
+				if s.AddIfAbsent(v) {
+					wins.Add(1)
+				}
+				s.Has(v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if wins.Load() != values {
+		t.Fatalf("got %d winning adds, want %d", wins.Load(), values)
+	}
+	if s.Size() != values {
+		t.Fatalf("Size got %d, want %d", s.Size(), values)
+	}
+}
+
+// The benchmarks compare deduplication (check-and-claim) against sync.Map.
+// "unique" claims a fresh key on every call, the write-heavy case; "repeat"
+// cycles through a small key space, so almost every call only reads.
+
+func benchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "tx-" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+func BenchmarkAddIfAbsent(b *testing.B) {
+	keys := benchKeys(1 << 16)
+	for _, mode := range []string{"unique", "repeat"} {
+		keyFor := func(i int64) string {
+			if mode == "repeat" {
+				return keys[i&1023]
+			}
+			return keys[i&(1<<16-1)] + "/" + strconv.FormatInt(i>>16, 10)
+		}
+
+		b.Run(mode+"/ConcurrentSet", func(b *testing.B) {
+			s := NewConcurrentSet[string](0)
+			var next atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					s.AddIfAbsent(keyFor(next.Add(1)))
+				}
+			})
+		})
+		b.Run(mode+"/sync.Map", func(b *testing.B) {
+			var m sync.Map
+			var next atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					m.LoadOrStore(keyFor(next.Add(1)), struct{}{})
+				}
+			})
+		})
+		b.Run(mode+"/MutexSet", func(b *testing.B) {
+			var mu sync.Mutex
+			s := NewSet[string]()
+			var next atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					k := keyFor(next.Add(1))
+					mu.Lock()
+					if !s.Has(k) {
+						s.Add(k)
+					}
+					mu.Unlock()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkHas(b *testing.B) {
+	keys := benchKeys(1024)
+	s := NewConcurrentSet[string](0)
+	var m sync.Map
+	for _, k := range keys {
+		s.Add(k)
+		m.Store(k, struct{}{})
+	}
+
+	b.Run("ConcurrentSet", func(b *testing.B) {
+		var next atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.Has(keys[next.Add(1)&1023])
+			}
+		})
+	})
+	b.Run("sync.Map", func(b *testing.B) {
+		var next atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				m.Load(keys[next.Add(1)&1023])
+			}
+		})
+	})
+}
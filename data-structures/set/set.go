@@ -1,5 +1,17 @@
 package datastructures
 
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Set is an unordered set of comparable values. The zero value is an empty
+// set ready to use, and a nil *Set reads as empty: it can be queried and
+// passed to the set operations, though not added to.
 type Set[T comparable] struct {
 	data map[T]struct{}
 }
@@ -8,20 +20,41 @@ func NewSet[T comparable]() *Set[T] {
 	return &Set[T]{data: make(map[T]struct{})}
 }
 
+// Of returns a set holding vals.
+func Of[T comparable](vals ...T) *Set[T] {
+	out := &Set[T]{data: make(map[T]struct{}, len(vals))}
+	for _, v := range vals {
+		out.data[v] = struct{}{}
+	}
+	return out
+}
+
 func (s *Set[T]) Add(v T) {
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+	}
 	s.data[v] = struct{}{}
 }
 
 func (s *Set[T]) Remove(v T) {
+	if s == nil {
+		return
+	}
 	delete(s.data, v)
 }
 
 func (s *Set[T]) Has(v T) bool {
+	if s == nil {
+		return false
+	}
 	_, exists := s.data[v]
 	return exists
 }
 
 func (s *Set[T]) Size() int {
+	if s == nil {
+		return 0
+	}
 	return len(s.data)
 }
 
@@ -36,6 +69,28 @@ func (s *Set[T]) Values() []T {
 	return out
 }
 
+// All yields the values in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s == nil {
+			return
+		}
+		for k := range s.data {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Set[T]) Clone() *Set[T] {
+	out := &Set[T]{data: make(map[T]struct{}, s.Size())}
+	for k := range s.All() {
+		out.data[k] = struct{}{}
+	}
+	return out
+}
+
 func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 	out := NewSet[T]()
 	if s != nil {
@@ -53,8 +108,13 @@ func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 
 func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
 	out := NewSet[T]()
-	for k := range s.data {
-		if other.Has(k) {
+	// Probe the larger set with the smaller one's values.
+	small, large := s, other
+	if small.Size() > large.Size() {
+		small, large = large, small
+	}
+	for k := range small.All() {
+		if large.Has(k) {
 			out.Add(k)
 		}
 	}
@@ -63,10 +123,105 @@ func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
 
 func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
 	out := NewSet[T]()
-	for k := range s.data {
+	for k := range s.All() {
 		if !other.Has(k) {
 			out.Add(k)
 		}
 	}
 	return out
-}
\ No newline at end of file
+}
+
+// SymmetricDifference returns the values in exactly one of s and other.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	out := s.Difference(other)
+	for k := range other.All() {
+		if !s.Has(k) {
+			out.Add(k)
+		}
+	}
+	return out
+}
+
+// IsSubset reports whether every value in s is also in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for k := range s.All() {
+		if !other.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether s holds every value in other.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether s and other hold the same values.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
+
+// MarshalJSON encodes the set as a JSON array. So that equal sets encode
+// identically, the array is sorted: by value if T is a number or string
+// type, and by the encoded elements otherwise.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	vals := s.Values()
+	if compare := orderedCompare[T](); compare != nil {
+		slices.SortFunc(vals, compare)
+		return json.Marshal(vals)
+	}
+
+	elems := make([]json.RawMessage, len(vals))
+	for i, v := range vals {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = b
+	}
+	slices.SortFunc(elems, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+	return json.Marshal(elems)
+}
+
+// UnmarshalJSON replaces the contents of s with the values in a JSON array.
+// Duplicates in the array are collapsed.
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var vals []T
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	s.data = make(map[T]struct{}, len(vals))
+	for _, v := range vals {
+		s.data[v] = struct{}{}
+	}
+	return nil
+}
+
+// orderedCompare returns a comparison function for T if its underlying type
+// is a number or string, and nil otherwise. T is only constrained to be
+// comparable, so this goes through reflection.
+func orderedCompare[T comparable]() func(a, b T) int {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}
+	case reflect.String:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}
+	}
+	return nil
+}
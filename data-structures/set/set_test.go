@@ -1,6 +1,9 @@
 package datastructures
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestSet_Dupes(t *testing.T) {
 	s := NewSet[string]()
@@ -88,4 +91,154 @@ func TestSet_Union_TableDriven(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestSet_NilSafety(t *testing.T) {
+	var nilSet *Set[int]
+	s := Of(1, 2)
+
+	if nilSet.Has(1) || nilSet.Size() != 0 {
+		t.Fatalf("expected nil set to read as empty")
+	}
+	if got := s.Intersection(nilSet).Size(); got != 0 {
+		t.Fatalf("Intersection with nil got size %d, want 0", got)
+	}
+	if got := nilSet.Intersection(s).Size(); got != 0 {
+		t.Fatalf("nil.Intersection got size %d, want 0", got)
+	}
+	if got := s.Difference(nilSet); !got.Equal(s) {
+		t.Fatalf("Difference with nil got %v, want %v", got.Values(), s.Values())
+	}
+	if got := nilSet.Difference(s).Size(); got != 0 {
+		t.Fatalf("nil.Difference got size %d, want 0", got)
+	}
+	if !nilSet.IsSubset(s) || !nilSet.Equal(NewSet[int]()) {
+		t.Fatalf("expected nil set to be an empty subset of everything")
+	}
+
+	var zero Set[string]
+	zero.Add("a")
+	if !zero.Has("a") {
+		t.Fatalf("expected Add on zero-value set to work")
+	}
+}
+
+func TestSet_Algebra(t *testing.T) {
+	a := Of(1, 2, 3, 4)
+	b := Of(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want *Set[int]
+	}{
+		{"intersection", a.Intersection(b), Of(3, 4)},
+		{"difference", a.Difference(b), Of(1, 2)},
+		{"symmetricDifference", a.SymmetricDifference(b), Of(1, 2, 5)},
+		{"symmetricDifferenceSelf", a.SymmetricDifference(a), Of[int]()},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.got.Equal(tc.want) {
+				t.Fatalf("got %v, want %v", tc.got.Values(), tc.want.Values())
+			}
+		})
+	}
+}
+
+func TestSet_SubsetSupersetEqual(t *testing.T) {
+	small := Of("a", "b")
+	big := Of("a", "b", "c")
+	other := Of("a", "x")
+
+	if !small.IsSubset(big) || big.IsSubset(small) {
+		t.Fatalf("IsSubset wrong for %v / %v", small.Values(), big.Values())
+	}
+	if !big.IsSuperset(small) || small.IsSuperset(big) {
+		t.Fatalf("IsSuperset wrong for %v / %v", big.Values(), small.Values())
+	}
+	if small.IsSubset(other) || !small.IsSubset(small) {
+		t.Fatalf("IsSubset wrong for %v / %v", small.Values(), other.Values())
+	}
+	if !small.Equal(Of("b", "a", "a")) || small.Equal(other) || small.Equal(big) {
+		t.Fatalf("Equal wrong for %v", small.Values())
+	}
+}
+
+func TestSet_CloneIsIndependent(t *testing.T) {
+	s := Of(1, 2)
+	c := s.Clone()
+	c.Add(3)
+	s.Remove(1)
+
+	if !s.Equal(Of(2)) || !c.Equal(Of(1, 2, 3)) {
+		t.Fatalf("got s=%v c=%v, want s=[2] c=[1 2 3]", s.Values(), c.Values())
+	}
+}
+
+func TestSet_All(t *testing.T) {
+	s := Of(1, 2, 3)
+	seen := NewSet[int]()
+	for v := range s.All() {
+		seen.Add(v)
+	}
+	if !seen.Equal(s) {
+		t.Fatalf("All yielded %v, want %v", seen.Values(), s.Values())
+	}
+
+	n := 0
+	for range s.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatalf("expected All to stop when the loop breaks")
+	}
+}
+
+func TestSet_JSON(t *testing.T) {
+	type point struct{ X, Y int }
+	type label string
+
+	tests := []struct {
+		name string
+		got  func() ([]byte, error)
+		want string
+	}{
+		{"ints", func() ([]byte, error) { return json.Marshal(Of(10, -2, 3, 1)) }, `[-2,1,3,10]`},
+		{"strings", func() ([]byte, error) { return json.Marshal(Of("pear", "apple", "fig")) }, `["apple","fig","pear"]`},
+		{"namedString", func() ([]byte, error) { return json.Marshal(Of[label]("b", "a")) }, `["a","b"]`},
+		{"floats", func() ([]byte, error) { return json.Marshal(Of(2.5, -1.0, 0.25)) }, `[-1,0.25,2.5]`},
+		{"structs", func() ([]byte, error) { return json.Marshal(Of(point{2, 1}, point{1, 2})) }, `[{"X":1,"Y":2},{"X":2,"Y":1}]`},
+		{"empty", func() ([]byte, error) { return json.Marshal(NewSet[int]()) }, `[]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.got()
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(b) != tc.want {
+				t.Fatalf("got %s, want %s", b, tc.want)
+			}
+		})
+	}
+
+	var s Set[int]
+	if err := json.Unmarshal([]byte(`[3,1,3,2]`), &s); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Fatalf("Unmarshal got %v, want [1 2 3]", s.Values())
+	}
+	if err := json.Unmarshal([]byte(`{"a":1}`), &s); err == nil {
+		t.Fatalf("expected Unmarshal of an object to fail")
+	}
+
+	// Sets embedded in structs round-trip too.
+	type doc struct{ Tags *Set[string] }
+	var d doc
+	if err := json.Unmarshal([]byte(`{"Tags":["x","y"]}`), &d); err != nil || !d.Tags.Equal(Of("x", "y")) {
+		t.Fatalf("Unmarshal into struct got (%v, %v)", d.Tags.Values(), err)
+	}
+}
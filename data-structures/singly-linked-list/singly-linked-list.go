@@ -2,19 +2,28 @@ package datastructures
 
 import "fmt"
 
-type SLNode struct {
-	Value int
-	Next  *SLNode
+type SLNode[T any] struct {
+	Value T
+	Next  *SLNode[T]
 }
 
-type LinkedList struct {
-	Head *SLNode
-	Tail *SLNode
+type LinkedList[T any] struct {
+	Head *SLNode[T]
+	Tail *SLNode[T]
+}
+
+// Build a list holding vals in order
+func FromSlice[T any](vals []T) *LinkedList[T] {
+	l := &LinkedList[T]{}
+	for _, v := range vals {
+		l.Append(v)
+	}
+	return l
 }
 
 // Add a node to the end of the list
-func (l *LinkedList) Append(v int) {
-	n := &SLNode{}
+func (l *LinkedList[T]) Append(v T) {
+	n := &SLNode[T]{Value: v}
 	if l.Head == nil {
 		l.Head = n
 		l.Tail = n
@@ -25,42 +34,175 @@ func (l *LinkedList) Append(v int) {
 }
 
 // Add a node to the front of the list
-func (l *LinkedList) Prepend(v int) {
-	n := &SLNode{Value: v, Next: l.Head}
+func (l *LinkedList[T]) Prepend(v T) {
+	n := &SLNode[T]{Value: v, Next: l.Head}
 	l.Head = n
 	if l.Tail == nil {
 		l.Tail = n
 	}
 }
 
-// remove the node matching the value v
-func (l *LinkedList) Delete(v int) {
+// remove the first node whose value satisfies match, reporting whether one was found
+func (l *LinkedList[T]) DeleteFunc(match func(T) bool) bool {
 	if l.Head == nil {
-		return
+		return false
 	}
-	if l.Head.Value == v {
+	if match(l.Head.Value) {
 		l.Head = l.Head.Next
 		if l.Head == nil {
 			l.Tail = nil
 		}
-		return
+		return true
 	}
 	prev := l.Head
 	for curr := l.Head.Next; curr != nil; curr = curr.Next {
-		if curr.Value == v {
+		if match(curr.Value) {
 			prev.Next = curr.Next
 			if curr == l.Tail {
 				l.Tail = prev
 			}
-			return
+			return true
 		}
 		prev = curr
 	}
+	return false
 }
 
-func (l *LinkedList) Print() {
+func (l *LinkedList[T]) ToSlice() []T {
+	var out []T
 	for n := l.Head; n != nil; n = n.Next {
-		fmt.Printf("%d ", n.Value)
+		out = append(out, n.Value)
+	}
+	return out
+}
+
+func (l *LinkedList[T]) Print() {
+	for n := l.Head; n != nil; n = n.Next {
+		fmt.Printf("%v ", n.Value)
 	}
 	fmt.Println()
 }
+
+// Reverse the list in place
+func (l *LinkedList[T]) Reverse() {
+	var prev *SLNode[T]
+	l.Tail = l.Head
+	for curr := l.Head; curr != nil; {
+		next := curr.Next
+		curr.Next = prev
+		prev, curr = curr, next
+	}
+	l.Head = prev
+}
+
+// Sort the list in place with a stable merge sort. cmp follows the
+// cmp.Compare convention.
+func (l *LinkedList[T]) Sort(cmp func(a, b T) int) {
+	l.Head = mergeSort(l.Head, cmp)
+	l.fixTail()
+}
+
+// Return the k-th value from the end (k=1 is the last value) in a single pass
+func (l *LinkedList[T]) KthFromEnd(k int) (T, bool) {
+	var zero T
+	if k <= 0 {
+		return zero, false
+	}
+	lead := l.Head
+	for i := 0; i < k; i++ {
+		if lead == nil {
+			return zero, false
+		}
+		lead = lead.Next
+	}
+	trail := l.Head
+	for lead != nil {
+		lead = lead.Next
+		trail = trail.Next
+	}
+	return trail.Value, true
+}
+
+// Report whether following Next from Head ever revisits a node (Floyd's tortoise and hare)
+func (l *LinkedList[T]) HasCycle() bool {
+	return meetingPoint(l.Head) != nil
+}
+
+// Return the first node of the cycle, or nil if the list has none
+func (l *LinkedList[T]) CycleStart() *SLNode[T] {
+	meet := meetingPoint(l.Head)
+	if meet == nil {
+		return nil
+	}
+	// The distance from Head to the cycle start equals the distance from
+	// the meeting point to the cycle start, going round the cycle.
+	a, b := l.Head, meet
+	for a != b {
+		a, b = a.Next, b.Next
+	}
+	return a
+}
+
+// Merge two sorted lists into one sorted list by relinking their nodes. Ties
+// take from a first, so the merge is stable. a and b are left empty.
+func Merge[T any](a, b *LinkedList[T], cmp func(a, b T) int) *LinkedList[T] {
+	out := &LinkedList[T]{Head: mergeNodes(a.Head, b.Head, cmp)}
+	out.fixTail()
+	a.Head, a.Tail = nil, nil
+	b.Head, b.Tail = nil, nil
+	return out
+}
+
+func (l *LinkedList[T]) fixTail() {
+	l.Tail = l.Head
+	for l.Tail != nil && l.Tail.Next != nil {
+		l.Tail = l.Tail.Next
+	}
+}
+
+func meetingPoint[T any](head *SLNode[T]) *SLNode[T] {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+		if slow == fast {
+			return slow
+		}
+	}
+	return nil
+}
+
+func mergeSort[T any](head *SLNode[T], cmp func(a, b T) int) *SLNode[T] {
+	if head == nil || head.Next == nil {
+		return head
+	}
+	// Split after the middle node; fast starts one ahead so the left half
+	// is never longer than the right.
+	slow, fast := head, head.Next
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+	}
+	right := slow.Next
+	slow.Next = nil
+	return mergeNodes(mergeSort(head, cmp), mergeSort(right, cmp), cmp)
+}
+
+func mergeNodes[T any](a, b *SLNode[T], cmp func(a, b T) int) *SLNode[T] {
+	var dummy SLNode[T]
+	tail := &dummy
+	for a != nil && b != nil {
+		if cmp(a.Value, b.Value) <= 0 {
+			tail.Next, a = a, a.Next
+		} else {
+			tail.Next, b = b, b.Next
+		}
+		tail = tail.Next
+	}
+	if a != nil {
+		tail.Next = a
+	} else {
+		tail.Next = b
+	}
+	return dummy.Next
+}
@@ -0,0 +1,136 @@
+package datastructures
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestLinkedList_AppendPrependDelete(t *testing.T) {
+	var l LinkedList[int]
+	l.Append(2)
+	l.Append(3)
+	l.Prepend(1)
+
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+
+	if !l.DeleteFunc(func(v int) bool { return v == 3 }) {
+		t.Fatalf("expected to delete 3")
+	}
+	if l.Tail.Value != 2 {
+		t.Fatalf("expected Tail to move back to 2, got %d", l.Tail.Value)
+	}
+	if l.DeleteFunc(func(v int) bool { return v == 42 }) {
+		t.Fatalf("expected no match for 42")
+	}
+}
+
+func TestLinkedList_Reverse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"empty", nil, nil},
+		{"single", []int{1}, []int{1}},
+		{"many", []int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := FromSlice(tc.in)
+			l.Reverse()
+			if got := l.ToSlice(); !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			if len(tc.want) > 0 && l.Tail.Value != tc.want[len(tc.want)-1] {
+				t.Fatalf("Tail got %v, want %v", l.Tail.Value, tc.want[len(tc.want)-1])
+			}
+		})
+	}
+}
+
+func TestLinkedList_SortIsStable(t *testing.T) {
+	type rec struct {
+		key int
+		id  string
+	}
+	l := FromSlice([]rec{{3, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {3, "e"}, {2, "f"}})
+	l.Sort(func(a, b rec) int { return cmp.Compare(a.key, b.key) })
+
+	want := []rec{{1, "b"}, {1, "d"}, {2, "c"}, {2, "f"}, {3, "a"}, {3, "e"}}
+	if got := l.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if l.Tail.Value != want[len(want)-1] {
+		t.Fatalf("Tail got %v, want %v", l.Tail.Value, want[len(want)-1])
+	}
+}
+
+func TestLinkedList_KthFromEnd(t *testing.T) {
+	l := FromSlice([]string{"a", "b", "c", "d"})
+	tests := []struct {
+		k    int
+		want string
+		ok   bool
+	}{
+		{1, "d", true}, {4, "a", true}, {5, "", false}, {0, "", false},
+	}
+	for _, tc := range tests {
+		if got, ok := l.KthFromEnd(tc.k); got != tc.want || ok != tc.ok {
+			t.Fatalf("KthFromEnd(%d) got (%q, %v), want (%q, %v)", tc.k, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestLinkedList_Cycle(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3, 4, 5})
+	if l.HasCycle() || l.CycleStart() != nil {
+		t.Fatalf("expected no cycle")
+	}
+
+	// 5 -> 3
+	start := l.Head.Next.Next
+	l.Tail.Next = start
+	if !l.HasCycle() {
+		t.Fatalf("expected cycle")
+	}
+	if got := l.CycleStart(); got != start {
+		t.Fatalf("CycleStart got %v, want node 3", got.Value)
+	}
+
+	// Whole list is the cycle.
+	l.Tail.Next = l.Head
+	if got := l.CycleStart(); got != l.Head {
+		t.Fatalf("CycleStart got %v, want head", got.Value)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want []int
+	}{
+		{"both_empty", nil, nil, nil},
+		{"a_empty", nil, []int{1, 2}, []int{1, 2}},
+		{"interleaved", []int{1, 3, 5}, []int{2, 4, 6, 8}, []int{1, 2, 3, 4, 5, 6, 8}},
+		{"dupes", []int{1, 2, 2}, []int{2, 3}, []int{1, 2, 2, 2, 3}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := FromSlice(tc.a), FromSlice(tc.b)
+			got := Merge(a, b, cmp.Compare[int])
+			if !slices.Equal(got.ToSlice(), tc.want) {
+				t.Fatalf("got %v, want %v", got.ToSlice(), tc.want)
+			}
+			if len(tc.want) > 0 && got.Tail.Value != tc.want[len(tc.want)-1] {
+				t.Fatalf("Tail got %v, want %v", got.Tail.Value, tc.want[len(tc.want)-1])
+			}
+			if a.Head != nil || b.Head != nil {
+				t.Fatalf("expected inputs to be emptied")
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const maxLevel = 32
+
+type node[K, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[node[K, V]]
+	mu          sync.Mutex
+	marked      atomic.Bool // logically deleted
+	fullyLinked atomic.Bool // linked in at every level
+}
+
+// SkipList is an ordered map that is safe for concurrent use. It is the
+// "lazy" skip list of Herlihy and Shavit: Search and iteration never lock,
+// and Insert and Delete lock only the nodes immediately before the one they
+// change, so writers on different parts of the list don't contend.
+type SkipList[K, V any] struct {
+	head *node[K, V]
+	cmp  func(a, b K) int
+	len  atomic.Int64
+}
+
+// New returns an empty skip list ordered by compare, which follows the
+// cmp.Compare convention.
+func New[K, V any](compare func(a, b K) int) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head: &node[K, V]{next: make([]atomic.Pointer[node[K, V]], maxLevel)},
+		cmp:  compare,
+	}
+}
+
+// NewOrdered returns an empty skip list for keys with a natural ordering.
+func NewOrdered[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return New[K, V](cmp.Compare[K])
+}
+
+func (s *SkipList[K, V]) Len() int {
+	return int(s.len.Load())
+}
+
+// Search returns the value stored under key.
+func (s *SkipList[K, V]) Search(key K) (V, bool) {
+	var preds, succs [maxLevel]*node[K, V]
+	if lvl := s.find(key, &preds, &succs); lvl >= 0 {
+		n := succs[lvl]
+		if n.fullyLinked.Load() && !n.marked.Load() {
+			return *n.value.Load(), true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert stores val under key, replacing any existing value. It reports
+// whether key was newly added.
+func (s *SkipList[K, V]) Insert(key K, val V) bool {
+	top := randomLevel()
+	var preds, succs [maxLevel]*node[K, V]
+	for {
+		if lvl := s.find(key, &preds, &succs); lvl >= 0 {
+			found := succs[lvl]
+			if found.marked.Load() {
+				// Being deleted; retry once it is unlinked.
+				runtime.Gosched()
+				continue
+			}
+			for !found.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			found.value.Store(&val)
+			return false
+		}
+
+		locked, valid := lockPreds(&preds, top, func(level int, pred *node[K, V]) bool {
+			succ := succs[level]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[level].Load() == succ
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+
+		n := &node[K, V]{key: key, next: make([]atomic.Pointer[node[K, V]], top)}
+		n.value.Store(&val)
+		for level := 0; level < top; level++ {
+			n.next[level].Store(succs[level])
+		}
+		for level := 0; level < top; level++ {
+			preds[level].next[level].Store(n)
+		}
+		n.fullyLinked.Store(true)
+		unlockPreds(&preds, locked)
+		s.len.Add(1)
+		return true
+	}
+}
+
+// Delete removes key and reports whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var preds, succs [maxLevel]*node[K, V]
+	var victim *node[K, V]
+	for {
+		lvl := s.find(key, &preds, &succs)
+		if victim == nil {
+			// Only a fully linked, unmarked node found at its own top level
+			// is safe to delete; anything else is mid-insert or mid-delete.
+			if lvl < 0 {
+				return false
+			}
+			victim = succs[lvl]
+			if !victim.fullyLinked.Load() || victim.marked.Load() || len(victim.next)-1 != lvl {
+				return false
+			}
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+		}
+
+		top := len(victim.next)
+		locked, valid := lockPreds(&preds, top, func(level int, pred *node[K, V]) bool {
+			return !pred.marked.Load() && pred.next[level].Load() == victim
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+
+		for level := top - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mu.Unlock()
+		unlockPreds(&preds, locked)
+		s.len.Add(-1)
+		return true
+	}
+}
+
+// All yields every entry in ascending key order. It never blocks writers;
+// entries inserted or deleted during iteration may or may not be seen.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := s.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+			if !n.fullyLinked.Load() || n.marked.Load() {
+				continue
+			}
+			if !yield(n.key, *n.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// find fills preds and succs with the nodes either side of key at every
+// level and returns the highest level at which key was found, or -1.
+func (s *SkipList[K, V]) find(key K, preds, succs *[maxLevel]*node[K, V]) int {
+	found := -1
+	pred := s.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && s.cmp(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found < 0 && curr != nil && s.cmp(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// lockPreds locks preds[0:top] bottom-up, skipping repeats, and checks valid
+// for each level. It returns the highest level whose pred it locked and
+// whether every level was valid.
+func lockPreds[K, V any](preds *[maxLevel]*node[K, V], top int, valid func(level int, pred *node[K, V]) bool) (int, bool) {
+	locked := -1
+	var prev *node[K, V]
+	for level := 0; level < top; level++ {
+		pred := preds[level]
+		if pred != prev {
+			pred.mu.Lock()
+			locked = level
+			prev = pred
+		}
+		if !valid(level, pred) {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+func unlockPreds[K, V any](preds *[maxLevel]*node[K, V], locked int) {
+	var prev *node[K, V]
+	for level := 0; level <= locked; level++ {
+		if pred := preds[level]; pred != prev {
+			pred.mu.Unlock()
+			prev = pred
+		}
+	}
+}
+
+// randomLevel returns a level in [1, maxLevel] with P(level > k) = 2^-k.
+func randomLevel() int {
+	return min(bits.TrailingZeros64(rand.Uint64())+1, maxLevel)
+}
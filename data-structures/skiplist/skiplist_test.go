@@ -0,0 +1,193 @@
+package skiplist
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSkipList_Basic(t *testing.T) {
+	s := NewOrdered[int, string]()
+	if _, ok := s.Search(1); ok {
+		t.Fatalf("expected Search on empty list to miss")
+	}
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		if !s.Insert(k, "v") {
+			t.Fatalf("expected Insert(%d) to add a new key", k)
+		}
+	}
+	if s.Insert(3, "three") {
+		t.Fatalf("expected Insert of an existing key to report false")
+	}
+	if v, ok := s.Search(3); !ok || v != "three" {
+		t.Fatalf("Search(3) got (%q, %v), want (\"three\", true)", v, ok)
+	}
+
+	if !s.Delete(5) || s.Delete(5) {
+		t.Fatalf("expected exactly one successful Delete(5)")
+	}
+	if s.Delete(42) {
+		t.Fatalf("expected Delete of a missing key to report false")
+	}
+
+	var keys []int
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	if want := []int{1, 3, 7, 9}; !slices.Equal(keys, want) {
+		t.Fatalf("All got %v, want %v", keys, want)
+	}
+	if s.Len() != 4 {
+		t.Fatalf("Len got %d, want 4", s.Len())
+	}
+}
+
+func TestSkipList_MatchesReferenceMap(t *testing.T) {
+	r := rand.New(rand.NewPCG(11, 12))
+	s := NewOrdered[int, int]()
+	ref := make(map[int]int)
+
+	for i := 0; i < 10000; i++ {
+		k := r.IntN(1000)
+		switch r.IntN(3) {
+		case 0:
+			if got, want := s.Delete(k), hasKey(ref, k); got != want {
+				t.Fatalf("Delete(%d) got %v, want %v", k, got, want)
+			}
+			delete(ref, k)
+		default:
+			s.Insert(k, i)
+			ref[k] = i
+		}
+	}
+	assertMatches(t, s, ref)
+}
+
+// Each writer owns the keys congruent to its index and mirrors its writes in
+// a private reference map, while readers scan and search the whole list.
+// Run with -race.
+func TestSkipList_ConcurrentStress(t *testing.T) {
+	const writers, keySpace, ops = 8, 2000, 5000
+	s := NewOrdered[int, int]()
+	refs := make([]map[int]int, writers)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for w := range writers {
+		refs[w] = make(map[int]int)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(uint64(w), 99))
+			for i := range ops {
+				k := r.IntN(keySpace/writers)*writers + w
+				if r.IntN(3) == 0 {
+					s.Delete(k)
+					delete(refs[w], k)
+				} else {
+					s.Insert(k, i)
+					refs[w][k] = i
+				}
+			}
+		}()
+	}
+
+	var readers sync.WaitGroup
+	for range 4 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				prev := -1
+				for k := range s.All() {
+					if k <= prev {
+						t.Errorf("All yielded %d after %d", k, prev)
+						return
+					}
+					prev = k
+				}
+				s.Search(rand.IntN(keySpace))
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+	readers.Wait()
+
+	ref := make(map[int]int)
+	for _, m := range refs {
+		maps.Copy(ref, m)
+	}
+	assertMatches(t, s, ref)
+}
+
+// All goroutines fight over the same handful of keys.
+func TestSkipList_ConcurrentContention(t *testing.T) {
+	s := NewOrdered[int, int]()
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				k := (w + i) % 8
+				if i%2 == 0 {
+					s.Insert(k, i)
+				} else {
+					s.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	n := 0
+	prev := -1
+	for k := range s.All() {
+		if k <= prev {
+			t.Fatalf("All yielded %d after %d", k, prev)
+		}
+		prev = k
+		n++
+	}
+	if n != s.Len() {
+		t.Fatalf("All yielded %d keys but Len is %d", n, s.Len())
+	}
+}
+
+func hasKey(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+func assertMatches(t *testing.T, s *SkipList[int, int], ref map[int]int) {
+	t.Helper()
+	if s.Len() != len(ref) {
+		t.Fatalf("Len got %d, want %d", s.Len(), len(ref))
+	}
+	want := slices.Sorted(maps.Keys(ref))
+	var got []int
+	for k, v := range s.All() {
+		if v != ref[k] {
+			t.Fatalf("value for %d got %d, want %d", k, v, ref[k])
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("keys got %v, want %v", got, want)
+	}
+	for k, v := range ref {
+		if got, ok := s.Search(k); !ok || got != v {
+			t.Fatalf("Search(%d) got (%d, %v), want (%d, true)", k, got, ok, v)
+		}
+	}
+}
@@ -28,4 +28,26 @@ func (s *Stack[T]) Peek() (T, bool) {
 
 func (s *Stack[T]) IsEmpty() bool {
 	return len(s.data) == 0
-}
\ No newline at end of file
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.data)
+}
+
+func (s *Stack[T]) Clear() {
+	clear(s.data)
+	s.data = s.data[:0]
+}
+
+// KeepTop drops all but the n most recently pushed values, for callers that
+// bound a stack's depth by forgetting the oldest entries.
+func (s *Stack[T]) KeepTop(n int) {
+	if n >= len(s.data) {
+		return
+	}
+	n = max(n, 0)
+	drop := len(s.data) - n
+	copy(s.data, s.data[drop:])
+	clear(s.data[n:])
+	s.data = s.data[:n]
+}
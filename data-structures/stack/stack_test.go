@@ -69,4 +69,49 @@ func TestStack_PeekDoesNotRemove(t *testing.T) {
 	if top, ok := s.Peek(); !ok || top != "Hello" {
 		t.Fatalf("Peek got (%q, %v), want (\"Hello\", true)", top, ok)
 	}
-}
\ No newline at end of file
+}
+
+func TestStack_LenClear(t *testing.T) {
+	var s Stack[int]
+	s.Push(1)
+	s.Push(2)
+	if s.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", s.Len())
+	}
+	s.Clear()
+	if s.Len() != 0 || !s.IsEmpty() {
+		t.Fatalf("expected empty stack after Clear, Len got %d", s.Len())
+	}
+	s.Push(3)
+	if top, ok := s.Pop(); !ok || top != 3 {
+		t.Fatalf("Pop after Clear got (%v, %v), want (3, true)", top, ok)
+	}
+}
+
+func TestStack_KeepTop(t *testing.T) {
+	var s Stack[int]
+	for i := 1; i <= 5; i++ {
+		s.Push(i)
+	}
+
+	s.KeepTop(10) // no-op
+	if s.Len() != 5 {
+		t.Fatalf("Len got %d, want 5", s.Len())
+	}
+
+	s.KeepTop(2)
+	if s.Len() != 2 {
+		t.Fatalf("Len got %d, want 2", s.Len())
+	}
+	for _, want := range []int{5, 4} {
+		if top, ok := s.Pop(); !ok || top != want {
+			t.Fatalf("Pop got (%v, %v), want (%v, true)", top, ok, want)
+		}
+	}
+
+	s.Push(1)
+	s.KeepTop(0)
+	if !s.IsEmpty() {
+		t.Fatalf("expected KeepTop(0) to empty the stack")
+	}
+}
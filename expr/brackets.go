@@ -0,0 +1,40 @@
+package expr
+
+import (
+	"fmt"
+
+	stack "github.com/oneill-c/go-toy-problems/data-structures/stack"
+)
+
+var closerFor = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+type bracket struct {
+	char rune
+	pos  int
+}
+
+// ValidateBrackets checks that every (, [ and { in s is closed by the
+// matching bracket in the right order; other characters are ignored. The
+// error is a *SyntaxError pointing at the first closer that doesn't match,
+// or else at the innermost opener left unclosed.
+func ValidateBrackets(s string) error {
+	var open stack.Stack[bracket]
+	for i, c := range s {
+		switch c {
+		case '(', '[', '{':
+			open.Push(bracket{char: c, pos: i})
+		case ')', ']', '}':
+			top, ok := open.Pop()
+			if !ok {
+				return &SyntaxError{Pos: i, Msg: fmt.Sprintf("unmatched %q", c)}
+			}
+			if want := closerFor[top.char]; c != want {
+				return &SyntaxError{Pos: i, Msg: fmt.Sprintf("expected %q to close %q at offset %d, got %q", want, top.char, top.pos, c)}
+			}
+		}
+	}
+	if top, ok := open.Pop(); ok {
+		return &SyntaxError{Pos: top.pos, Msg: fmt.Sprintf("unclosed %q", top.char)}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+// Package expr evaluates arithmetic expressions such as "2 * (x + 1) ^ 2".
+//
+// Expressions are tokenized, converted from infix to reverse Polish notation
+// with Dijkstra's shunting-yard algorithm, and the RPN is evaluated with a
+// value stack. Supported are numbers, variables, parentheses, unary minus and
+// the binary operators + - * / % ^.
+//
+// Binary + and - bind loosest, then * / and %, all left-associative. Unary
+// minus binds tighter, so -x*y is (-x)*y, and ^ binds tightest and is
+// right-associative: -2^2 is -4 and 2^3^2 is 2^9.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	stack "github.com/oneill-c/go-toy-problems/data-structures/stack"
+)
+
+var (
+	ErrUnknownVariable = errors.New("unknown variable")
+	ErrDivisionByZero  = errors.New("division by zero")
+)
+
+// SyntaxError reports malformed input. Pos is the byte offset in the input
+// of the offending token.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Pos, e.Msg)
+}
+
+// EvalError reports a failure while evaluating a well-formed expression,
+// such as an unbound variable. Pos is the byte offset of the token at fault.
+type EvalError struct {
+	Pos int
+	Err error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("eval error at offset %d: %v", e.Pos, e.Err)
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
+
+type Kind int
+
+const (
+	Number Kind = iota
+	Ident
+	Op
+	LParen
+	RParen
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Number:
+		return "number"
+	case Ident:
+		return "identifier"
+	case Op:
+		return "operator"
+	case LParen:
+		return "'('"
+	case RParen:
+		return "')'"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+type Token struct {
+	Kind  Kind
+	Text  string
+	Pos   int
+	Num   float64 // value of a Number token
+	Unary bool    // an Op token that is prefix minus rather than subtraction
+}
+
+func (t Token) String() string {
+	if t.Unary {
+		return "neg"
+	}
+	return t.Text
+}
+
+// Tokenize splits s into tokens. Whether a '-' is unary or binary is decided
+// later, by Parse.
+func Tokenize(s string) ([]Token, error) {
+	var toks []Token
+	for i := 0; i < len(s); {
+		c := s[i]
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, Token{Kind: LParen, Text: "(", Pos: i})
+			i++
+		case c == ')':
+			toks = append(toks, Token{Kind: RParen, Text: ")", Pos: i})
+			i++
+		case strings.IndexByte("+-*/%^", c) >= 0:
+			toks = append(toks, Token{Kind: Op, Text: string(c), Pos: i})
+			i++
+		case isDigit(c) || c == '.':
+			j := i
+			for j < len(s) && (isDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			// Exponent, as in 1e3 or 2.5E-4.
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && isDigit(s[k]) {
+					for j = k; j < len(s) && isDigit(s[j]); j++ {
+					}
+				}
+			}
+			n, err := strconv.ParseFloat(s[i:j], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("invalid number %q", s[i:j])}
+			}
+			toks = append(toks, Token{Kind: Number, Text: s[i:j], Pos: i, Num: n})
+			i = j
+		case isIdentRune(r, true):
+			j := i + size
+			for j < len(s) {
+				r, size := utf8.DecodeRuneInString(s[j:])
+				if !isIdentRune(r, false) {
+					break
+				}
+				j += size
+			}
+			toks = append(toks, Token{Kind: Ident, Text: s[i:j], Pos: i})
+			i = j
+		default:
+			return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return toks, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentRune reports whether r may appear in an identifier: letters in any
+// script and '_', plus ASCII digits after the first rune.
+func isIdentRune(r rune, first bool) bool {
+	return r == '_' || unicode.IsLetter(r) || !first && r >= '0' && r <= '9'
+}
+
+// Expr is a parsed expression, ready to be evaluated any number of times.
+type Expr struct {
+	rpn []Token
+}
+
+// Parse tokenizes s and converts it to RPN, checking that operators and
+// operands alternate and that parentheses balance.
+func Parse(s string) (*Expr, error) {
+	toks, err := Tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	rpn, err := toRPN(toks, len(s))
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{rpn: rpn}, nil
+}
+
+// Eval parses and evaluates s in one step.
+func Eval(s string, vars map[string]float64) (float64, error) {
+	e, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval(vars)
+}
+
+// RPN returns the expression in reverse Polish notation, with tokens
+// separated by spaces and unary minus written as "neg".
+func (e *Expr) RPN() string {
+	parts := make([]string, len(e.rpn))
+	for i, t := range e.rpn {
+		parts[i] = t.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Eval evaluates the expression, looking variables up in vars.
+func (e *Expr) Eval(vars map[string]float64) (float64, error) {
+	var vals stack.Stack[float64]
+	for _, t := range e.rpn {
+		switch t.Kind {
+		case Number:
+			vals.Push(t.Num)
+		case Ident:
+			v, ok := vars[t.Text]
+			if !ok {
+				return 0, &EvalError{Pos: t.Pos, Err: fmt.Errorf("%w %q", ErrUnknownVariable, t.Text)}
+			}
+			vals.Push(v)
+		case Op:
+			// Parse has checked the operand counts, so these pops succeed.
+			b, _ := vals.Pop()
+			if t.Unary {
+				vals.Push(-b)
+				continue
+			}
+			a, _ := vals.Pop()
+			v, err := apply(t, a, b)
+			if err != nil {
+				return 0, err
+			}
+			vals.Push(v)
+		}
+	}
+	v, _ := vals.Pop()
+	return v, nil
+}
+
+func apply(t Token, a, b float64) (float64, error) {
+	switch t.Text {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, &EvalError{Pos: t.Pos, Err: ErrDivisionByZero}
+		}
+		return a / b, nil
+	case "%":
+		if b == 0 {
+			return 0, &EvalError{Pos: t.Pos, Err: ErrDivisionByZero}
+		}
+		return math.Mod(a, b), nil
+	case "^":
+		return math.Pow(a, b), nil
+	}
+	panic("expr: unknown operator " + t.Text)
+}
+
+func precedence(t Token) int {
+	if t.Unary {
+		return 3
+	}
+	switch t.Text {
+	case "+", "-":
+		return 1
+	case "*", "/", "%":
+		return 2
+	}
+	return 4 // ^
+}
+
+func rightAssoc(t Token) bool {
+	return t.Unary || t.Text == "^"
+}
+
+// toRPN is the shunting-yard algorithm. It tracks whether the next token
+// should be an operand or an operator, which both catches malformed input
+// like "1 2" or "1 +" and tells unary minus apart from subtraction. end is
+// the input length, used to position errors at the end of the input.
+func toRPN(toks []Token, end int) ([]Token, error) {
+	var (
+		out       []Token
+		ops       stack.Stack[Token]
+		wantValue = true
+	)
+	for _, t := range toks {
+		switch t.Kind {
+		case Number, Ident:
+			if !wantValue {
+				return nil, &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("unexpected %s %q", t.Kind, t.Text)}
+			}
+			out = append(out, t)
+			wantValue = false
+		case LParen:
+			if !wantValue {
+				return nil, &SyntaxError{Pos: t.Pos, Msg: "unexpected '('"}
+			}
+			ops.Push(t)
+		case RParen:
+			if wantValue {
+				return nil, &SyntaxError{Pos: t.Pos, Msg: "expected operand before ')'"}
+			}
+			for {
+				top, ok := ops.Pop()
+				if !ok {
+					return nil, &SyntaxError{Pos: t.Pos, Msg: "unmatched ')'"}
+				}
+				if top.Kind == LParen {
+					break
+				}
+				out = append(out, top)
+			}
+		case Op:
+			if wantValue {
+				if t.Text != "-" {
+					return nil, &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("expected operand before %q", t.Text)}
+				}
+				// A prefix operator has no left operand, so nothing on
+				// the stack can be waiting for it to finish.
+				t.Unary = true
+				ops.Push(t)
+				continue
+			}
+			for {
+				top, ok := ops.Peek()
+				if !ok || top.Kind == LParen {
+					break
+				}
+				if p, q := precedence(top), precedence(t); p < q || (p == q && rightAssoc(t)) {
+					break
+				}
+				ops.Pop()
+				out = append(out, top)
+			}
+			ops.Push(t)
+			wantValue = true
+		}
+	}
+	if wantValue {
+		return nil, &SyntaxError{Pos: end, Msg: "unexpected end of expression"}
+	}
+	for {
+		top, ok := ops.Pop()
+		if !ok {
+			break
+		}
+		if top.Kind == LParen {
+			return nil, &SyntaxError{Pos: top.Pos, Msg: "unclosed '('"}
+		}
+		out = append(out, top)
+	}
+	return out, nil
+}
@@ -0,0 +1,172 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"x": 3, "y": 4, "rate_2": 0.5, "é": 2, "µ2": 5}
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"42", 42},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"100 / 10 / 5", 2},
+		{"7 % 4", 3},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"--3", 3},
+		{"2 * -x", -6},
+		{"2 ^ -1", 0.5},
+		{"x * x + y * y", 25},
+		{"rate_2 * 1e3", 500},
+		{"1.5E-1 + .05", 0.2},
+		{"((x))", 3},
+		{"é + 1", 3},
+		{"µ2 * é", 10},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := Eval(tc.in, vars)
+			if err != nil {
+				t.Fatalf("Eval(%q): %v", tc.in, err)
+			}
+			if math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("Eval(%q) got %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse_RPN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1 + 2 * 3", "1 2 3 * +"},
+		{"(1 + 2) * 3", "1 2 + 3 *"},
+		{"a - b + c", "a b - c +"},
+		{"a ^ b ^ c", "a b c ^ ^"},
+		{"-a ^ 2", "a 2 ^ neg"},
+		{"-a * b", "a neg b *"},
+	}
+	for _, tc := range tests {
+		e, err := Parse(tc.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.in, err)
+		}
+		if got := e.RPN(); got != tc.want {
+			t.Fatalf("RPN(%q) got %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"(1 + 2", 0},
+		{"1 + (2 * (3)", 4},
+		{"1 + 2)", 5},
+		{"1 2", 2},
+		{"* 2", 0},
+		{"()", 1},
+		{"2 (3)", 2},
+		{"1 $ 2", 2},
+		{"1.2.3", 0},
+		{"é €", 3},
+		{"x€", 1},
+	}
+	for _, tc := range tests {
+		_, err := Parse(tc.in)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("Parse(%q) got %v, want *SyntaxError", tc.in, err)
+		}
+		if se.Pos != tc.pos {
+			t.Fatalf("Parse(%q) error at %d, want %d (%v)", tc.in, se.Pos, tc.pos, err)
+		}
+	}
+
+	// The error names the whole character, not one of its bytes.
+	if _, err := Parse("x€"); err == nil || !strings.Contains(err.Error(), "'€'") {
+		t.Fatalf("Parse(\"x€\") got %v, want an error naming '€'", err)
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want error
+		pos  int
+	}{
+		{"1 + z", ErrUnknownVariable, 4},
+		{"1 / (2 - 2)", ErrDivisionByZero, 2},
+		{"5 % 0", ErrDivisionByZero, 2},
+	}
+	for _, tc := range tests {
+		_, err := Eval(tc.in, nil)
+		var ee *EvalError
+		if !errors.As(err, &ee) || !errors.Is(err, tc.want) {
+			t.Fatalf("Eval(%q) got %v, want %v", tc.in, err, tc.want)
+		}
+		if ee.Pos != tc.pos {
+			t.Fatalf("Eval(%q) error at %d, want %d", tc.in, ee.Pos, tc.pos)
+		}
+	}
+}
+
+func TestExpr_ReuseWithDifferentVars(t *testing.T) {
+	e, err := Parse("x * 2 + 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x, want := range map[float64]float64{0: 1, 1: 3, 10: 21} {
+		if got, err := e.Eval(map[string]float64{"x": x}); err != nil || got != want {
+			t.Fatalf("Eval(x=%v) got (%v, %v), want (%v, nil)", x, got, err, want)
+		}
+	}
+}
+
+func TestValidateBrackets(t *testing.T) {
+	tests := []struct {
+		in  string
+		ok  bool
+		pos int
+	}{
+		{"", true, 0},
+		{"no brackets", true, 0},
+		{"([]{[()]})", true, 0},
+		{"f(a[1], {b: 2})", true, 0},
+		{")", false, 0},
+		{"(]", false, 1},
+		{"([)]", false, 2},
+		{"{[()]", false, 0},
+		{"(()", false, 0},
+		{"(()(", false, 3},
+		{"é(", false, 2}, // offsets are in bytes
+	}
+	for _, tc := range tests {
+		err := ValidateBrackets(tc.in)
+		if tc.ok {
+			if err != nil {
+				t.Fatalf("ValidateBrackets(%q): %v", tc.in, err)
+			}
+			continue
+		}
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Pos != tc.pos {
+			t.Fatalf("ValidateBrackets(%q) got %v, want *SyntaxError at %d", tc.in, err, tc.pos)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package history records reversible commands so they can be undone and
+// redone, like the edit history of a text editor.
+//
+// A History keeps two stacks: commands that can be undone, most recent on
+// top, and commands that were undone and can be redone. Doing a new command
+// clears the redo stack, since the undone commands no longer apply to the
+// state it produces.
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	stack "github.com/oneill-c/go-toy-problems/data-structures/stack"
+)
+
+var (
+	ErrNothingToUndo = errors.New("history: nothing to undo")
+	ErrNothingToRedo = errors.New("history: nothing to redo")
+	ErrInTransaction = errors.New("history: transaction in progress")
+	ErrNoTransaction = errors.New("history: no transaction in progress")
+)
+
+// Command is a reversible change. Undo must restore the state from before
+// Do, and Do must be safe to call again after Undo, for redo.
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+type funcCommand struct {
+	do, undo func() error
+}
+
+func (c funcCommand) Do() error   { return c.do() }
+func (c funcCommand) Undo() error { return c.undo() }
+
+// Func builds a Command from a pair of functions.
+func Func(do, undo func() error) Command {
+	return funcCommand{do: do, undo: undo}
+}
+
+// group is several commands done and undone as one.
+type group []Command
+
+func (g group) Do() error {
+	for i, c := range g {
+		if err := c.Do(); err != nil {
+			return errors.Join(err, undoAll(g[:i]))
+		}
+	}
+	return nil
+}
+
+func (g group) Undo() error {
+	for i := len(g) - 1; i >= 0; i-- {
+		if err := g[i].Undo(); err != nil {
+			return errors.Join(err, redoAll(g[i+1:]))
+		}
+	}
+	return nil
+}
+
+// undoAll undoes cmds in reverse order, to back out a partly applied group.
+func undoAll(cmds []Command) error {
+	var errs []error
+	for i := len(cmds) - 1; i >= 0; i-- {
+		if err := cmds[i].Undo(); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// redoAll redoes cmds in order, to back out a partly undone group.
+func redoAll(cmds []Command) error {
+	var errs []error
+	for _, c := range cmds {
+		if err := c.Do(); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// History is an undo/redo history. It is not safe for concurrent use.
+type History struct {
+	undo  stack.Stack[Command]
+	redo  stack.Stack[Command]
+	limit int
+	stale int // forgotten steps still at the bottom of undo
+	tx    group
+	depth int // nesting level of Begin calls
+}
+
+// New returns a history that remembers at most limit undoable steps,
+// forgetting the oldest beyond that. A limit <= 0 means no limit. Forgotten
+// steps are dropped in batches of limit, so recording a step stays O(1)
+// amortized at the cost of holding up to twice limit commands.
+func New(limit int) *History {
+	return &History{limit: limit}
+}
+
+// Do runs c and, if it succeeds, records it as the most recent step. Inside
+// a transaction, c becomes part of the transaction's step instead.
+func (h *History) Do(c Command) error {
+	if err := c.Do(); err != nil {
+		return err
+	}
+	if h.depth > 0 {
+		h.tx = append(h.tx, c)
+		return nil
+	}
+	h.record(c)
+	return nil
+}
+
+// Undo reverts the most recent step. If the step fails to undo, it stays
+// on the undo stack.
+func (h *History) Undo() error {
+	if h.depth > 0 {
+		return ErrInTransaction
+	}
+	if h.undo.Len() == h.stale {
+		return ErrNothingToUndo
+	}
+	c, _ := h.undo.Pop()
+	if err := c.Undo(); err != nil {
+		h.undo.Push(c)
+		return err
+	}
+	h.redo.Push(c)
+	return nil
+}
+
+// Redo reapplies the most recently undone step. If the step fails to redo,
+// it stays on the redo stack.
+func (h *History) Redo() error {
+	if h.depth > 0 {
+		return ErrInTransaction
+	}
+	c, ok := h.redo.Pop()
+	if !ok {
+		return ErrNothingToRedo
+	}
+	if err := c.Do(); err != nil {
+		h.redo.Push(c)
+		return err
+	}
+	h.undo.Push(c)
+	return nil
+}
+
+func (h *History) CanUndo() bool {
+	return h.depth == 0 && h.UndoLen() > 0
+}
+
+func (h *History) CanRedo() bool {
+	return h.depth == 0 && !h.redo.IsEmpty()
+}
+
+// UndoLen returns the number of steps that can be undone.
+func (h *History) UndoLen() int {
+	return h.undo.Len() - h.stale
+}
+
+// RedoLen returns the number of steps that can be redone.
+func (h *History) RedoLen() int {
+	return h.redo.Len()
+}
+
+// Begin starts a transaction: the commands done until the matching Commit
+// are recorded as a single step, undone and redone together. Transactions
+// nest; only the outermost Commit records the step.
+func (h *History) Begin() {
+	h.depth++
+}
+
+// Commit ends the innermost transaction. A transaction in which no commands
+// were done records nothing.
+func (h *History) Commit() error {
+	if h.depth == 0 {
+		return ErrNoTransaction
+	}
+	h.depth--
+	if h.depth > 0 || len(h.tx) == 0 {
+		return nil
+	}
+	g := h.tx
+	h.tx = nil
+	h.record(g)
+	return nil
+}
+
+// Rollback ends the transaction by undoing every command done in it, most
+// recent first, and recording nothing. With nested transactions it rolls
+// back the outermost one, since the inner steps can't stand on their own.
+func (h *History) Rollback() error {
+	if h.depth == 0 {
+		return ErrNoTransaction
+	}
+	g := h.tx
+	h.tx, h.depth = nil, 0
+	return undoAll(g)
+}
+
+func (h *History) record(c Command) {
+	h.undo.Push(c)
+	h.redo.Clear()
+	if h.limit > 0 && h.UndoLen() > h.limit {
+		h.stale++
+		if h.stale >= h.limit {
+			h.undo.KeepTop(h.limit)
+			h.stale = 0
+		}
+	}
+}
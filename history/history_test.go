@@ -0,0 +1,221 @@
+package history
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// doc is a list of words edited through commands, for testing.
+type doc struct {
+	words []string
+}
+
+func (d *doc) appendCmd(w string) Command {
+	return Func(
+		func() error { d.words = append(d.words, w); return nil },
+		func() error { d.words = d.words[:len(d.words)-1]; return nil },
+	)
+}
+
+func (d *doc) check(t *testing.T, want ...string) {
+	t.Helper()
+	if !slices.Equal(d.words, want) {
+		t.Fatalf("words got %v, want %v", d.words, want)
+	}
+}
+
+var errBoom = errors.New("boom")
+
+func failing() Command {
+	return Func(func() error { return errBoom }, func() error { return nil })
+}
+
+func TestHistory_UndoRedo(t *testing.T) {
+	var d doc
+	h := New(0)
+	if err := h.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo on empty history got %v, want ErrNothingToUndo", err)
+	}
+
+	h.Do(d.appendCmd("a"))
+	h.Do(d.appendCmd("b"))
+	h.Do(d.appendCmd("c"))
+	d.check(t, "a", "b", "c")
+
+	h.Undo()
+	h.Undo()
+	d.check(t, "a")
+	if h.UndoLen() != 1 || h.RedoLen() != 2 {
+		t.Fatalf("UndoLen/RedoLen got (%d, %d), want (1, 2)", h.UndoLen(), h.RedoLen())
+	}
+
+	h.Redo()
+	d.check(t, "a", "b")
+
+	// A new command forks the history: "c" can no longer be redone.
+	h.Do(d.appendCmd("x"))
+	d.check(t, "a", "b", "x")
+	if h.CanRedo() {
+		t.Fatalf("expected Do to clear the redo stack")
+	}
+	if err := h.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("Redo got %v, want ErrNothingToRedo", err)
+	}
+}
+
+func TestHistory_FailedDoIsNotRecorded(t *testing.T) {
+	var d doc
+	h := New(0)
+	h.Do(d.appendCmd("a"))
+	h.Undo()
+
+	if err := h.Do(failing()); !errors.Is(err, errBoom) {
+		t.Fatalf("Do got %v, want errBoom", err)
+	}
+	if h.CanUndo() || !h.CanRedo() {
+		t.Fatalf("expected a failed Do to leave the history untouched")
+	}
+}
+
+func TestHistory_FailedUndoStaysOnStack(t *testing.T) {
+	h := New(0)
+	fail := true
+	h.Do(Func(func() error { return nil }, func() error {
+		if fail {
+			return errBoom
+		}
+		return nil
+	}))
+
+	if err := h.Undo(); !errors.Is(err, errBoom) {
+		t.Fatalf("Undo got %v, want errBoom", err)
+	}
+	if !h.CanUndo() {
+		t.Fatalf("expected the step to remain undoable")
+	}
+	fail = false
+	if err := h.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+}
+
+func TestHistory_Limit(t *testing.T) {
+	var d doc
+	h := New(2)
+	for _, w := range []string{"a", "b", "c", "d"} {
+		h.Do(d.appendCmd(w))
+	}
+	if h.UndoLen() != 2 {
+		t.Fatalf("UndoLen got %d, want 2", h.UndoLen())
+	}
+	h.Undo()
+	h.Undo()
+	if err := h.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo past the limit got %v, want ErrNothingToUndo", err)
+	}
+	d.check(t, "a", "b")
+
+	// One step past the limit: the forgotten step is still on the stack
+	// until a whole batch is dropped, but must not be undoable.
+	h.Redo()
+	h.Do(d.appendCmd("e"))
+	h.Do(d.appendCmd("f"))
+	if h.UndoLen() != 2 {
+		t.Fatalf("UndoLen got %d, want 2", h.UndoLen())
+	}
+	h.Undo()
+	h.Undo()
+	if h.CanUndo() {
+		t.Fatalf("expected CanUndo to be false past the limit")
+	}
+	if err := h.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo past the limit got %v, want ErrNothingToUndo", err)
+	}
+	d.check(t, "a", "b", "c")
+}
+
+func TestHistory_Transaction(t *testing.T) {
+	var d doc
+	h := New(0)
+	h.Do(d.appendCmd("a"))
+
+	h.Begin()
+	h.Do(d.appendCmd("b"))
+	h.Begin() // nested: folds into the outer transaction
+	h.Do(d.appendCmd("c"))
+	h.Commit()
+	if err := h.Undo(); !errors.Is(err, ErrInTransaction) {
+		t.Fatalf("Undo inside a transaction got %v, want ErrInTransaction", err)
+	}
+	h.Do(d.appendCmd("d"))
+	if err := h.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	d.check(t, "a", "b", "c", "d")
+	if h.UndoLen() != 2 {
+		t.Fatalf("UndoLen got %d, want 2", h.UndoLen())
+	}
+
+	h.Undo()
+	d.check(t, "a")
+	h.Redo()
+	d.check(t, "a", "b", "c", "d")
+
+	if err := h.Commit(); !errors.Is(err, ErrNoTransaction) {
+		t.Fatalf("Commit without Begin got %v, want ErrNoTransaction", err)
+	}
+
+	// An empty transaction records nothing.
+	h.Begin()
+	h.Commit()
+	if h.UndoLen() != 2 {
+		t.Fatalf("UndoLen after empty transaction got %d, want 2", h.UndoLen())
+	}
+}
+
+func TestHistory_Rollback(t *testing.T) {
+	var d doc
+	h := New(0)
+	h.Do(d.appendCmd("a"))
+
+	h.Begin()
+	h.Do(d.appendCmd("b"))
+	h.Begin()
+	h.Do(d.appendCmd("c"))
+	if err := h.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	d.check(t, "a")
+	if h.UndoLen() != 1 {
+		t.Fatalf("UndoLen got %d, want 1", h.UndoLen())
+	}
+	if err := h.Rollback(); !errors.Is(err, ErrNoTransaction) {
+		t.Fatalf("second Rollback got %v, want ErrNoTransaction", err)
+	}
+}
+
+func TestHistory_PartialGroupRedoIsRolledBack(t *testing.T) {
+	var d doc
+	h := New(0)
+	fail := false
+	h.Begin()
+	h.Do(d.appendCmd("a"))
+	h.Do(Func(func() error {
+		if fail {
+			return errBoom
+		}
+		return nil
+	}, func() error { return nil }))
+	h.Commit()
+	h.Undo()
+
+	fail = true
+	if err := h.Redo(); !errors.Is(err, errBoom) {
+		t.Fatalf("Redo got %v, want errBoom", err)
+	}
+	d.check(t)
+	if !h.CanRedo() {
+		t.Fatalf("expected the failed group to stay redoable")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oneill-c/go-toy-problems/history"
+)
+
+var ErrEmailTaken = errors.New("email already belongs to another user")
+
+// putUser inserts or replaces a user, remembering what it replaced so the
+// edit can be undone.
+type putUser struct {
+	us      *UserStore
+	user    User
+	prev    User
+	existed bool
+}
+
+func (c *putUser) Do() error {
+	if c.user.Name == "" || c.user.Email == "" || c.user.Phone == "" {
+		return errors.New("user needs a name, phone and email")
+	}
+	prev, existed := c.us.UserDB[c.user.Name]
+	if _, taken := c.us.seenEmails[c.user.Email]; taken && (!existed || prev.Email != c.user.Email) {
+		return fmt.Errorf("%w: %s", ErrEmailTaken, c.user.Email)
+	}
+	c.prev, c.existed = prev, existed
+	if existed {
+		delete(c.us.seenEmails, prev.Email)
+	}
+	c.us.seenEmails[c.user.Email] = struct{}{}
+	c.us.UserDB[c.user.Name] = c.user
+	return nil
+}
+
+func (c *putUser) Undo() error {
+	delete(c.us.seenEmails, c.user.Email)
+	if !c.existed {
+		delete(c.us.UserDB, c.user.Name)
+		return nil
+	}
+	c.us.seenEmails[c.prev.Email] = struct{}{}
+	c.us.UserDB[c.prev.Name] = c.prev
+	return nil
+}
+
+// deleteUser removes a user, remembering it so the edit can be undone.
+type deleteUser struct {
+	us   *UserStore
+	name string
+	prev User
+}
+
+func (c *deleteUser) Do() error {
+	u, ok := c.us.UserDB[c.name]
+	if !ok {
+		return fmt.Errorf("no user named %q", c.name)
+	}
+	c.prev = u
+	delete(c.us.seenEmails, u.Email)
+	delete(c.us.UserDB, c.name)
+	return nil
+}
+
+func (c *deleteUser) Undo() error {
+	c.us.seenEmails[c.prev.Email] = struct{}{}
+	c.us.UserDB[c.prev.Name] = c.prev
+	return nil
+}
+
+// EditableStore wraps a UserStore so that edits made through it can be
+// undone and redone.
+type EditableStore struct {
+	*UserStore
+	History *history.History
+}
+
+func NewEditableStore(us *UserStore, depth int) *EditableStore {
+	return &EditableStore{UserStore: us, History: history.New(depth)}
+}
+
+func (es *EditableStore) PutUser(u User) error {
+	return es.History.Do(&putUser{us: es.UserStore, user: u})
+}
+
+func (es *EditableStore) DeleteUser(name string) error {
+	return es.History.Do(&deleteUser{us: es.UserStore, name: name})
+}
+
+// RenameUser changes a user's key as a single undoable step.
+func (es *EditableStore) RenameUser(oldName, newName string) error {
+	u, ok := es.UserDB[oldName]
+	if !ok {
+		return fmt.Errorf("no user named %q", oldName)
+	}
+	if _, ok := es.UserDB[newName]; ok {
+		return fmt.Errorf("user %q already exists", newName)
+	}
+	u.Name = newName
+
+	es.History.Begin()
+	if err := es.DeleteUser(oldName); err != nil {
+		return errors.Join(err, es.History.Rollback())
+	}
+	if err := es.PutUser(u); err != nil {
+		return errors.Join(err, es.History.Rollback())
+	}
+	return es.History.Commit()
+}
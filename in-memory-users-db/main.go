@@ -203,4 +203,33 @@ func main() {
 
 	fmt.Println(userStore.GetUsers())
 	fmt.Println(userStore.GetUserById("Joker"))
+
+	// reversible edits
+	editable := NewEditableStore(userStore, 10)
+	if err := editable.PutUser(User{ Name: "Joker", Phone: "555-555-4444", Email: "joker@gmail.com" }); err != nil {
+		panic(err)
+	}
+	fmt.Println(editable.GetUserById("Joker"))
+	if err := editable.RenameUser("Robin", "Nightwing"); err != nil {
+		panic(err)
+	}
+	fmt.Println(editable.GetUsers())
+
+	// undo the rename (one step), then the phone change
+	if err := editable.History.Undo(); err != nil {
+		panic(err)
+	}
+	if err := editable.History.Undo(); err != nil {
+		panic(err)
+	}
+	fmt.Println(editable.GetUsers())
+
+	// redo the phone change
+	if err := editable.History.Redo(); err != nil {
+		panic(err)
+	}
+	fmt.Println(editable.GetUserById("Joker"))
+
+	// conflicting email is rejected and not recorded
+	fmt.Println(editable.PutUser(User{ Name: "Harley", Phone: "555-555-5555", Email: "joker@gmail.com" }))
 }
\ No newline at end of file
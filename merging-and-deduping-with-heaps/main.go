@@ -1,8 +1,9 @@
 package main
 
 import (
-	"container/heap"
 	"fmt"
+
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
 )
 
 type User struct {
@@ -17,28 +18,13 @@ type MergeItem struct {
 	Index  int
 }
 
-type MergeHeap []MergeItem
-
-func (h MergeHeap) Len() int           { return len(h) }
-func (h MergeHeap) Less(i, j int) bool { return h[i].User.Score > h[j].User.Score }
-func (h MergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *MergeHeap) Push(x any)        { *h = append(*h, x.(MergeItem)) }
-func (h *MergeHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[:n-1]
-	return x
-}
-
 func MergAndDedupTopK(sources [][]User, k int) []User {
-	h := &MergeHeap{}
-	heap.Init(h)
+	h := heap.New(func(a, b MergeItem) bool { return a.User.Score > b.User.Score })
 
 	// seed heap with the first element from each list
 	for i, list := range sources {
 		if len(list) > 0 {
-			heap.Push(h, MergeItem{User: list[0], ListID: i, Index: 0})
+			h.Push(MergeItem{User: list[0], ListID: i, Index: 0})
 		}
 	}
 
@@ -47,7 +33,7 @@ func MergAndDedupTopK(sources [][]User, k int) []User {
 
 	for h.Len() > 0 && len(result) < k {
 		// Repeatidly pop next best item
-		item := heap.Pop(h).(MergeItem)
+		item, _ := h.Pop()
 
 		// Dedupe
 		u := item.User
@@ -60,7 +46,7 @@ func MergAndDedupTopK(sources [][]User, k int) []User {
 		nextIdx := item.Index + 1
 		if nextIdx < len(sources[item.ListID]) {
 			nextUser := sources[item.ListID][nextIdx]
-			heap.Push(h, MergeItem{User: nextUser, ListID: item.ListID, Index: nextIdx})
+			h.Push(MergeItem{User: nextUser, ListID: item.ListID, Index: nextIdx})
 		}
 	}
 	return result
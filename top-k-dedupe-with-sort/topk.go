@@ -1,9 +1,10 @@
 package topkdedupewithsort
 
 import (
-	"container/heap"
 	"slices"
 	"sort"
+
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
 )
 
 // User is the record we’re ranking by Score.
@@ -13,38 +14,14 @@ type User struct {
 	Score int
 }
 
-// ---------------- Min-Heap for Users (by Score, tie-break ID) ----------------
-
-// UserMinHeap maintains the *smallest* score at index 0.
-// Tie-break: smaller ID is considered "less" to keep ordering deterministic.
-type UserMinHeap []User
-
-// TODO: implement heap.Interface methods:
-//
-// func (h UserMinHeap) Len() int            { /* TODO */ }
-// func (h UserMinHeap) Less(i, j int) bool  { /* TODO: return h[i].Score < h[j].Score || (scores equal && h[i].ID < h[j].ID) */ }
-// func (h UserMinHeap) Swap(i, j int)       { /* TODO */ }
-// func (h *UserMinHeap) Push(x any)         { /* TODO: append x.(User) */ }
-// func (h *UserMinHeap) Pop() any           { /* TODO: remove and return last element */ }
+// ---------------- Min-Heap ordering for Users (by Score, tie-break ID) ----------------
 
-// Optional: Peek helper (not required by heap.Interface).
-// func (h UserMinHeap) Peek() User { return h[0] }
-
-func (h UserMinHeap) Len() int { return len(h) }
-func (h UserMinHeap) Less(i, j int) bool {
-	if h[i].Score != h[j].Score {
-		return h[i].Score < h[j].Score // min-heap by score
+// userLess keeps the *smallest* score at the top of the heap.
+func userLess(a, b User) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score // min-heap by score
 	}
-	return h[i].ID > h[j].ID // tie tweak so Reverse() yields ID asc
-}
-func (h UserMinHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
-func (h *UserMinHeap) Push(x any)   { *h = append(*h, x.(User)) }
-func (h *UserMinHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[:n-1]
-	return x
+	return a.ID > b.ID // tie tweak so Reverse() yields ID asc
 }
 
 // ---------------- Top-K (O(N log K)) ----------------
@@ -89,27 +66,27 @@ func TopKUsers(users []User, k int) []User {
 		return out
 	}
 
-	h := &UserMinHeap{}
-	heap.Init(h)
+	h := heap.New(userLess)
 
 	// Stream through users maintaining a bounded min-heap of size k.
 	for _, u := range users {
 		if h.Len() < k {
-			heap.Push(h, u)
+			h.Push(u)
 			continue
 		}
 		// Compare with the smallest among current top-K.
-		min := (*h)[0]
+		min, _ := h.Peek()
 		if u.Score > min.Score || (u.Score == min.Score && u.ID < min.ID) {
-			heap.Pop(h)
-			heap.Push(h, u)
+			h.Pop()
+			h.Push(u)
 		}
 	}
 
 	// Extract K users (ascending by score because it's a min-heap).
 	out := make([]User, 0, h.Len())
 	for h.Len() > 0 {
-		out = append(out, heap.Pop(h).(User))
+		u, _ := h.Pop()
+		out = append(out, u)
 	}
 
 	// Reverse to descending, or just sort K items for deterministic order.
@@ -1,11 +1,12 @@
 package main
 
 import (
-	"container/heap"
 	"fmt"
 	"math/rand"
 	"sort"
 	"time"
+
+	"github.com/oneill-c/go-toy-problems/data-structures/heap"
 )
 
 // ------------- Shared -------------
@@ -36,34 +37,22 @@ func TopKSort(users []User, k int) []User {
 }
 
 // ------------- Incremental Sort (MinHeap) -------------
-type UserMinHeap []User
-
-func (h UserMinHeap) Len() int           { return len(h) }
-func (h UserMinHeap) Less(i, j int) bool { return h[i].Score < h[j].Score }
-func (h UserMinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *UserMinHeap) Push(x any)        { *h = append(*h, x.(User)) }
-func (h *UserMinHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[:n-1]
-	return x
-}
-
 func TopKHeap(users []User, k int) []User {
-	h := &UserMinHeap{}
-	heap.Init(h)
+	if k <= 0 {
+		return nil
+	}
+	h := heap.New(func(a, b User) bool { return a.Score < b.Score })
 	for _, u := range users {
 		if h.Len() < k {
-			heap.Push(h, u)
-		} else if u.Score > (*h)[0].Score {
-			heap.Pop(h)
-			heap.Push(h, u)
+			h.Push(u)
+		} else if top, _ := h.Peek(); u.Score > top.Score {
+			h.Pop()
+			h.Push(u)
 		}
 	}
 	out := make([]User, h.Len())
 	for i := len(out) - 1; i >= 0; i-- {
-		out[i] = heap.Pop(h).(User)
+		out[i], _ = h.Pop()
 	}
 	return out
 }